@@ -0,0 +1,152 @@
+// Package bradleyterry fits ratings to a whole set of results at once by maximum likelihood, rather than updating them one game at a time. The fitted ratings do not depend on the order in which the games were played, which makes them suitable for reviewing a completed season. Ratings are reported on the same 400 point logistic scale as the elo package, so a difference of 400 points means the higher rated player is expected to score ten times as often as the lower rated one.
+package bradleyterry
+
+import (
+	"math"
+
+	"github.com/dylrich/rating"
+)
+
+const (
+
+	// DefaultCenter is the standard value for Config.Center.
+	DefaultCenter = 1500.0
+
+	// DefaultScale is the standard value for Config.Scale.
+	DefaultScale = 400.0
+
+	// DefaultMaxIterations is the standard value for Config.MaxIterations.
+	DefaultMaxIterations = 100
+
+	// DefaultConvergenceTolerance is the standard value for Config.ConvergenceTolerance.
+	DefaultConvergenceTolerance = 0.000000001
+)
+
+// Config contains the settings used to fit a set of results. Any zero field is replaced with its package default when the Config is used, and the optional terms are disabled while their fields are left at zero.
+type Config struct {
+
+	// Center is the average rating of the fitted players, or the mean of the prior when PriorDeviation is set.
+	Center float64
+
+	// Scale is the logistic scale of the ratings. A difference of Scale points means the higher rated player is expected to score ten times as often as the lower rated one.
+	Scale float64
+
+	// Advantage is the number of rating points by which the side with home field, the first move, or a similar edge is expected to outperform its rating. It is applied using the Advantage of each rating.Game, and is used as the starting point when EstimateAdvantage is set.
+	Advantage float64
+
+	// EstimateAdvantage fits Advantage jointly with the ratings instead of holding it fixed. It has no effect if no game has an Advantage.
+	EstimateAdvantage bool
+
+	// Draws uses Davidson's extension of the Bradley-Terry model, which treats a score of 0.5 as a draw with its own probability rather than as half a win and half a loss. The draw parameter is fitted jointly with the ratings. When Draws is set, every score must be 0, 0.5, or 1.
+	Draws bool
+
+	// PriorDeviation places a normal prior with this standard deviation, in rating points, around Center on every player's rating. The prior regularises the fit so that players with few games are pulled towards the average, and keeps the ratings of players who have won or lost every game finite. Zero disables the prior.
+	PriorDeviation float64
+
+	// HalfLife is the number of periods after which the weight of a game in the fit has halved, measured back from the latest period in the results. It allows older results to count for less than recent ones. Zero weights every game equally.
+	HalfLife float64
+
+	// MaxIterations caps the number of Newton iterations.
+	MaxIterations int
+
+	// ConvergenceTolerance is the increase in the log likelihood below which the fit is considered to have converged.
+	ConvergenceTolerance float64
+}
+
+// Fit contains the maximum likelihood ratings for a set of results. Deviations are the approximate standard errors of each rating, taken from the curvature of the likelihood at its peak. Draw is Davidson's draw parameter ν, where the chance of a draw between two evenly matched players is ν / (2 + ν), and is zero unless Config.Draws was set. LogLikelihood is the weighted log likelihood of the results under the fitted model, excluding the prior.
+type Fit struct {
+	Ratings, Deviations            map[string]float64
+	Advantage, Draw, LogLikelihood float64
+	Iterations                     int
+}
+
+// DefaultConfig returns a Config populated with the package default values.
+func DefaultConfig() Config {
+	return Config{
+		Center:               DefaultCenter,
+		Scale:                DefaultScale,
+		MaxIterations:        DefaultMaxIterations,
+		ConvergenceTolerance: DefaultConvergenceTolerance,
+	}
+}
+
+// Fit finds the ratings that make the games most likely under the Bradley-Terry model using Newton's method. Every player who appears in the games is rated, and the order of the games only matters through their Period when HalfLife is set. Without a prior, a player who has won or lost every game has no finite maximum likelihood rating, and their rating keeps growing until MaxIterations is reached.
+func (c Config) Fit(games []rating.Game) Fit {
+	c = c.withDefaults()
+	m := c.newModel(games)
+	fit := Fit{Ratings: make(map[string]float64), Deviations: make(map[string]float64)}
+	if len(m.players) == 0 {
+		return fit
+	}
+	x := make([]float64, m.size())
+	if m.advantage >= 0 {
+		x[m.advantage] = c.Advantage * c.q()
+	}
+	objective, gradient, hessian := m.evaluate(x)
+	for fit.Iterations < c.MaxIterations {
+		inverse := invert(negate(hessian))
+		if inverse == nil {
+			break
+		}
+		step := multiply(inverse, gradient)
+		next, nextObjective := x, objective
+		for halvings := 0; halvings < 30; halvings++ {
+			next = add(x, step)
+			nextObjective, _, _ = m.evaluate(next)
+			if nextObjective >= objective {
+				break
+			}
+			for i := range step {
+				step[i] /= 2
+			}
+		}
+		fit.Iterations++
+		if nextObjective < objective {
+			break
+		}
+		improvement := nextObjective - objective
+		x = next
+		objective, gradient, hessian = m.evaluate(x)
+		if improvement < c.ConvergenceTolerance {
+			break
+		}
+	}
+
+	covariance := invert(negate(hessian))
+	for i, id := range m.players {
+		fit.Ratings[id] = c.Center + x[i]/c.q()
+		if covariance != nil {
+			fit.Deviations[id] = math.Sqrt(covariance[i][i]) / c.q()
+		}
+	}
+	fit.Advantage = c.Advantage
+	if m.advantage >= 0 {
+		fit.Advantage = x[m.advantage] / c.q()
+	}
+	if c.Draws {
+		fit.Draw = math.Exp(x[m.draw])
+	}
+	fit.LogLikelihood = m.logLikelihood(x)
+	return fit
+}
+
+func (c Config) withDefaults() Config {
+	if c.Center == 0 {
+		c.Center = DefaultCenter
+	}
+	if c.Scale == 0 {
+		c.Scale = DefaultScale
+	}
+	if c.MaxIterations == 0 {
+		c.MaxIterations = DefaultMaxIterations
+	}
+	if c.ConvergenceTolerance == 0 {
+		c.ConvergenceTolerance = DefaultConvergenceTolerance
+	}
+	return c
+}
+
+// q converts rating points to the natural logarithm of a player's strength.
+func (c Config) q() float64 {
+	return math.Ln10 / c.Scale
+}
@@ -0,0 +1,163 @@
+package bradleyterry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/simulate"
+)
+
+func repeat(g rating.Game, n int) []rating.Game {
+	games := make([]rating.Game, n)
+	for i := range games {
+		games[i] = g
+	}
+	return games
+}
+
+func series(wins, losses, draws int) []rating.Game {
+	games := repeat(rating.Game{Player: "a", Opponent: "b", Score: 1}, wins)
+	games = append(games, repeat(rating.Game{Player: "b", Opponent: "a", Score: 1}, losses)...)
+	return append(games, repeat(rating.Game{Player: "a", Opponent: "b", Score: 0.5}, draws)...)
+}
+
+func TestFit(t *testing.T) {
+	// When a beats b three times out of four, the maximum likelihood difference is 400·log10(3).
+	fit := DefaultConfig().Fit(series(3, 1, 0))
+	gap := 400 * math.Log10(3)
+	if math.Abs(fit.Ratings["a"]-(1500+gap/2)) > 0.001 || math.Abs(fit.Ratings["b"]-(1500-gap/2)) > 0.001 {
+		t.Log(fit)
+		t.Fail()
+	}
+	if fit.Deviations["a"] <= 0 || math.Abs(fit.Deviations["a"]-fit.Deviations["b"]) > 0.001 {
+		t.Log(fit)
+		t.Fail()
+	}
+
+	// Without Draws, a draw counts as half a win and half a loss.
+	fit = DefaultConfig().Fit(series(1, 0, 2))
+	if math.Abs(fit.Ratings["a"]-fit.Ratings["b"]-400*math.Log10(2)) > 0.001 {
+		t.Log(fit)
+		t.Fail()
+	}
+	if math.Abs(fit.LogLikelihood-(2*math.Log(2.0/3)+math.Log(1.0/3))) > 0.000001 {
+		t.Log(fit.LogLikelihood)
+		t.Fail()
+	}
+}
+
+func TestOrder(t *testing.T) {
+	games := []rating.Game{
+		{Player: "a", Opponent: "b", Score: 1},
+		{Player: "b", Opponent: "c", Score: 1},
+		{Player: "c", Opponent: "a", Score: 1},
+		{Player: "a", Opponent: "c", Score: 1},
+		{Player: "b", Opponent: "a", Score: 0},
+	}
+	reversed := make([]rating.Game, len(games))
+	for i, g := range games {
+		reversed[len(games)-1-i] = g
+	}
+	c := DefaultConfig()
+	forward, backward := c.Fit(games), c.Fit(reversed)
+	for _, id := range []string{"a", "b", "c"} {
+		if math.Abs(forward.Ratings[id]-backward.Ratings[id]) > 0.001 {
+			t.Log(forward, backward)
+			t.Fail()
+		}
+	}
+	if forward.Ratings["a"] <= forward.Ratings["b"] || math.Abs(forward.Ratings["b"]-forward.Ratings["c"]) > 0.001 {
+		t.Log(forward)
+		t.Fail()
+	}
+}
+
+func TestDraws(t *testing.T) {
+	// For wins w, losses l, and draws d, Davidson's model fits the difference as log(w/l) and ν as d/sqrt(wl).
+	c := DefaultConfig()
+	c.Draws = true
+	fit := c.Fit(series(4, 1, 2))
+	if math.Abs(fit.Ratings["a"]-fit.Ratings["b"]-400*math.Log10(4)) > 0.001 || math.Abs(fit.Draw-1) > 0.0001 {
+		t.Log(fit)
+		t.Fail()
+	}
+}
+
+func TestAdvantage(t *testing.T) {
+	// Each player wins three of four games at home, so they are equally rated and the advantage is worth 400·log10(3).
+	games := repeat(rating.Game{Player: "a", Opponent: "b", Score: 1, Advantage: 1}, 3)
+	games = append(games, rating.Game{Player: "a", Opponent: "b", Score: 0, Advantage: 1})
+	games = append(games, repeat(rating.Game{Player: "a", Opponent: "b", Score: 0, Advantage: -1}, 3)...)
+	games = append(games, rating.Game{Player: "b", Opponent: "a", Score: 0, Advantage: 1})
+	c := DefaultConfig()
+	c.EstimateAdvantage = true
+	fit := c.Fit(games)
+	if math.Abs(fit.Advantage-400*math.Log10(3)) > 0.001 || math.Abs(fit.Ratings["a"]-fit.Ratings["b"]) > 0.001 {
+		t.Log(fit)
+		t.Fail()
+	}
+
+	// A fixed advantage is applied without being changed.
+	c = DefaultConfig()
+	c.Advantage = 400 * math.Log10(3)
+	fit = c.Fit(games)
+	if fit.Advantage != c.Advantage || math.Abs(fit.Ratings["a"]-fit.Ratings["b"]) > 0.001 {
+		t.Log(fit)
+		t.Fail()
+	}
+}
+
+func TestPrior(t *testing.T) {
+	games := repeat(rating.Game{Player: "a", Opponent: "b", Score: 1}, 3)
+	c := DefaultConfig()
+	c.PriorDeviation = 200
+	fit := c.Fit(games)
+	a, b := fit.Ratings["a"], fit.Ratings["b"]
+	if math.IsInf(a, 0) || a <= 1500 || a > 2000 || math.Abs(a-1500+b-1500) > 0.001 {
+		t.Log(fit)
+		t.Fail()
+	}
+
+	// A wider prior pulls less.
+	c.PriorDeviation = 400
+	if wide := c.Fit(games); wide.Ratings["a"] <= a {
+		t.Log(wide, fit)
+		t.Fail()
+	}
+}
+
+func TestHalfLife(t *testing.T) {
+	// With a half life of ten periods, b's recent win counts twice as much as a's win ten periods earlier.
+	games := []rating.Game{
+		{Period: 0, Player: "a", Opponent: "b", Score: 1},
+		{Period: 10, Player: "b", Opponent: "a", Score: 1},
+	}
+	c := DefaultConfig()
+	c.HalfLife = 10
+	fit := c.Fit(games)
+	if math.Abs(fit.Ratings["b"]-fit.Ratings["a"]-400*math.Log10(2)) > 0.001 {
+		t.Log(fit)
+		t.Fail()
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	fit := DefaultConfig().Fit(nil)
+	if len(fit.Ratings) != 0 || fit.Iterations != 0 {
+		t.Log(fit)
+		t.Fail()
+	}
+}
+
+func TestSimulatedAdvantage(t *testing.T) {
+	games := simulate.League{Players: 20, Periods: 10, GamesPerPeriod: 200, Advantage: 60}.Games(1)
+	c := DefaultConfig()
+	c.EstimateAdvantage = true
+	c.PriorDeviation = 400
+	fit := c.Fit(games)
+	if math.Abs(fit.Advantage-60) > 15 || len(fit.Ratings) != 20 {
+		t.Log(fit.Advantage, len(fit.Ratings))
+		t.Fail()
+	}
+}
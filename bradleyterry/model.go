@@ -0,0 +1,250 @@
+package bradleyterry
+
+import (
+	"math"
+
+	"github.com/dylrich/rating"
+)
+
+// model is the log likelihood of a set of games as a function of a parameter vector. The vector holds the natural log strength θ of each player, followed by the advantage in the same units when it is estimated, and the log of the draw parameter when draws are modelled.
+type model struct {
+	config    Config
+	players   []string
+	games     []game
+	advantage int
+	draw      int
+}
+
+type game struct {
+	player, opponent         int
+	score, advantage, weight float64
+}
+
+func (c Config) newModel(games []rating.Game) *model {
+	m := &model{config: c, advantage: -1, draw: -1}
+	index := make(map[string]int)
+	get := func(id string) int {
+		if i, ok := index[id]; ok {
+			return i
+		}
+		index[id] = len(m.players)
+		m.players = append(m.players, id)
+		return index[id]
+	}
+	latest := 0
+	for i, g := range games {
+		if i == 0 || g.Period > latest {
+			latest = g.Period
+		}
+	}
+	for _, g := range games {
+		weight := 1.0
+		if c.HalfLife > 0 {
+			weight = math.Pow(0.5, float64(latest-g.Period)/c.HalfLife)
+		}
+		m.games = append(m.games, game{
+			player:    get(g.Player),
+			opponent:  get(g.Opponent),
+			score:     g.Score,
+			advantage: g.Advantage,
+			weight:    weight,
+		})
+	}
+	n := len(m.players)
+	if c.EstimateAdvantage && hasAdvantage(games) {
+		m.advantage = n
+		n++
+	}
+	if c.Draws {
+		m.draw = n
+	}
+	return m
+}
+
+func hasAdvantage(games []rating.Game) bool {
+	for _, g := range games {
+		if g.Advantage != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *model) size() int {
+	n := len(m.players)
+	if m.advantage >= 0 {
+		n++
+	}
+	if m.draw >= 0 {
+		n++
+	}
+	return n
+}
+
+// logLikelihood returns the weighted log likelihood of the games, excluding the prior.
+func (m *model) logLikelihood(x []float64) float64 {
+	total := 0.0
+	for _, g := range m.games {
+		ll, _, _, _, _, _ := m.game(x, g)
+		total += g.weight * ll
+	}
+	return total
+}
+
+// game returns the log likelihood of a single game, along with its first and second derivatives with respect to the strength difference d and the log draw parameter λ.
+func (m *model) game(x []float64, g game) (ll, dd, dl, ddd, ddl, dll float64) {
+	advantage := m.config.Advantage * m.config.q()
+	if m.advantage >= 0 {
+		advantage = x[m.advantage]
+	}
+	d := x[g.player] - x[g.opponent] + g.advantage*advantage
+	u, v, nu := math.Exp(d/2), math.Exp(-d/2), 0.0
+	if m.draw >= 0 {
+		nu = math.Exp(x[m.draw])
+	}
+	denom := u + v + nu
+	ll = (g.score-0.5)*d - math.Log(denom)
+	dd = (g.score - 0.5) - (u-v)/(2*denom)
+	ddd = -((u+v)*denom - math.Pow(u-v, 2)) / (4 * math.Pow(denom, 2))
+	if m.draw >= 0 {
+		if g.score == 0.5 {
+			ll += x[m.draw]
+			dl = 1
+		}
+		dl -= nu / denom
+		ddl = (u - v) * nu / (2 * math.Pow(denom, 2))
+		dll = -nu * (u + v) / math.Pow(denom, 2)
+	}
+	return ll, dd, dl, ddd, ddl, dll
+}
+
+// evaluate returns the objective being maximised, which is the log likelihood plus the log prior, along with its gradient and Hessian. Without a prior, the ratings are only defined up to a constant, so a penalty on their sum pins their average to Center without changing their differences.
+func (m *model) evaluate(x []float64) (float64, []float64, [][]float64) {
+	n := len(x)
+	gradient := make([]float64, n)
+	hessian := make([][]float64, n)
+	for i := range hessian {
+		hessian[i] = make([]float64, n)
+	}
+	objective := 0.0
+	for _, g := range m.games {
+		ll, dd, dl, ddd, ddl, dll := m.game(x, g)
+		w := g.weight
+		objective += w * ll
+
+		// partials holds the derivative of d with respect to each parameter it depends on.
+		indices := []int{g.player, g.opponent}
+		partials := []float64{1, -1}
+		if m.advantage >= 0 {
+			indices = append(indices, m.advantage)
+			partials = append(partials, g.advantage)
+		}
+		for a, i := range indices {
+			gradient[i] += w * dd * partials[a]
+			for b, j := range indices {
+				hessian[i][j] += w * ddd * partials[a] * partials[b]
+			}
+			if m.draw >= 0 {
+				hessian[i][m.draw] += w * ddl * partials[a]
+				hessian[m.draw][i] += w * ddl * partials[a]
+			}
+		}
+		if m.draw >= 0 {
+			gradient[m.draw] += w * dl
+			hessian[m.draw][m.draw] += w * dll
+		}
+	}
+
+	players := len(m.players)
+	if m.config.PriorDeviation > 0 {
+		precision := 1 / math.Pow(m.config.PriorDeviation*m.config.q(), 2)
+		for i := 0; i < players; i++ {
+			objective -= precision * math.Pow(x[i], 2) / 2
+			gradient[i] -= precision * x[i]
+			hessian[i][i] -= precision
+		}
+	} else {
+		sum := 0.0
+		for i := 0; i < players; i++ {
+			sum += x[i]
+		}
+		objective -= math.Pow(sum, 2) / 2
+		for i := 0; i < players; i++ {
+			gradient[i] -= sum
+			for j := 0; j < players; j++ {
+				hessian[i][j]--
+			}
+		}
+	}
+	return objective, gradient, hessian
+}
+
+// invert returns the inverse of a square matrix using Gauss-Jordan elimination with partial pivoting, or nil if the matrix is singular.
+func invert(a [][]float64) [][]float64 {
+	n := len(a)
+	m := make([][]float64, n)
+	inverse := make([][]float64, n)
+	for i := range a {
+		m[i] = append([]float64(nil), a[i]...)
+		inverse[i] = make([]float64, n)
+		inverse[i][i] = 1
+	}
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if m[pivot][col] == 0 {
+			return nil
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		inverse[col], inverse[pivot] = inverse[pivot], inverse[col]
+		scale := m[col][col]
+		for j := 0; j < n; j++ {
+			m[col][j] /= scale
+			inverse[col][j] /= scale
+		}
+		for row := 0; row < n; row++ {
+			if row == col || m[row][col] == 0 {
+				continue
+			}
+			factor := m[row][col]
+			for j := 0; j < n; j++ {
+				m[row][j] -= factor * m[col][j]
+				inverse[row][j] -= factor * inverse[col][j]
+			}
+		}
+	}
+	return inverse
+}
+
+func negate(a [][]float64) [][]float64 {
+	n := make([][]float64, len(a))
+	for i := range a {
+		n[i] = make([]float64, len(a[i]))
+		for j := range a[i] {
+			n[i][j] = -a[i][j]
+		}
+	}
+	return n
+}
+
+func multiply(a [][]float64, x []float64) []float64 {
+	y := make([]float64, len(a))
+	for i := range a {
+		for j := range x {
+			y[i] += a[i][j] * x[j]
+		}
+	}
+	return y
+}
+
+func add(x, y []float64) []float64 {
+	z := make([]float64, len(x))
+	for i := range x {
+		z[i] = x[i] + y[i]
+	}
+	return z
+}
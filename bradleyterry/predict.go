@@ -0,0 +1,28 @@
+package bradleyterry
+
+import (
+	"math"
+
+	"github.com/dylrich/rating"
+)
+
+// Expected returns the expected score of a player with the given rating against an opponent, which is their probability of winning when draws are counted as half a win. It uses the Bradley-Terry model without the draw term. Any advantage held by either side should be added to that side's rating by the caller.
+func (c Config) Expected(rating, opponentRating float64) float64 {
+	c = c.withDefaults()
+	return 1 / (1 + math.Exp(-c.q()*(rating-opponentRating)))
+}
+
+// Gap returns the rating difference at which the higher rated player has the given expected score. It is the inverse of Expected. Probabilities below 0.5 give a negative gap.
+func (c Config) Gap(probability float64) float64 {
+	c = c.withDefaults()
+	return math.Log(probability/(1-probability)) / c.q()
+}
+
+// Probabilities returns the separate chances of a win, a draw, and a loss for a player with the given rating against an opponent under Davidson's model, where draw is the draw parameter ν reported by Fit.Draw. A draw parameter of zero gives the Bradley-Terry model without draws. Any advantage held by either side should be added to that side's rating by the caller.
+func (c Config) Probabilities(playerRating, opponentRating, draw float64) rating.Probabilities {
+	c = c.withDefaults()
+	d := c.q() * (playerRating - opponentRating)
+	win, loss := math.Exp(d/2), math.Exp(-d/2)
+	total := win + loss + draw
+	return rating.Probabilities{Win: win / total, Draw: draw / total, Loss: loss / total}
+}
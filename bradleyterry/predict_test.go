@@ -0,0 +1,41 @@
+package bradleyterry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	c := DefaultConfig()
+	if e := c.Expected(1900, 1500); math.Abs(e-10.0/11) > 0.000001 {
+		t.Log(e)
+		t.Fail()
+	}
+	if g := c.Gap(10.0 / 11); math.Abs(g-400) > 0.000001 {
+		t.Log(g)
+		t.Fail()
+	}
+	for _, probability := range []float64{0.1, 0.5, 0.75, 0.99} {
+		if e := c.Expected(1500+c.Gap(probability), 1500); math.Abs(e-probability) > 0.000001 {
+			t.Log(probability, e)
+			t.Fail()
+		}
+	}
+}
+
+func TestProbabilities(t *testing.T) {
+	c := DefaultConfig()
+	c.Draws = true
+	fit := c.Fit(series(4, 1, 2))
+	p := c.Probabilities(fit.Ratings["a"], fit.Ratings["b"], fit.Draw)
+
+	// The fitted model reproduces the observed frequencies of four wins, one loss, and two draws.
+	if math.Abs(p.Win-4.0/7) > 0.0001 || math.Abs(p.Draw-2.0/7) > 0.0001 || math.Abs(p.Loss-1.0/7) > 0.0001 {
+		t.Log(p)
+		t.Fail()
+	}
+	if p := c.Probabilities(1900, 1500, 0); math.Abs(p.Win-10.0/11) > 0.000001 || p.Draw != 0 {
+		t.Log(p)
+		t.Fail()
+	}
+}
@@ -0,0 +1,57 @@
+package elo
+
+import (
+	"errors"
+)
+
+// ErrNotReplayable is returned by Void and Amend when the calling Player's History in the current rating period contains a result from RateFreeForAll or RateTeams. Those results depend on the other participants' ratings and weights at the time of the match, which are not kept in History, so the period cannot be replayed.
+var ErrNotReplayable = errors.New("elo: free-for-all and team results cannot be replayed")
+
+// ErrNoResult is returned by Void and Amend when the calling Player's History has no result at the given index.
+var ErrNoResult = errors.New("elo: no result at that index of the History")
+
+// Void removes the result at index i of the calling Player's History, for example because a referee has overturned it, and recalculates the player's Rating, GamesPlayed, and PeakRating for the current rating period by replaying the remaining results in order from the player's Parameters. Each result is replayed against the opponent rating stored with it in the same way it was first applied: as Play for a HeadToHead result, and as Score for a MarginOfVictory result. If there is no result at index i, ErrNoResult is returned, and if the period contains a FreeForAll or TeamMatch result, ErrNotReplayable is returned. In either case the player is left unchanged. The returned Outcome describes the change caused by the removal. To void the result for the opponent as well, call Opponent.Void with the index of the same match in their History.
+func (p *Player) Void(i int) (Outcome, error) {
+	if i < 0 || i >= len(p.History) {
+		return Outcome{}, ErrNoResult
+	}
+	history := make([]Result, 0, len(p.History)-1)
+	history = append(history, p.History[:i]...)
+	history = append(history, p.History[i+1:]...)
+	return p.recalculate(history)
+}
+
+// Amend replaces the score of the result at index i of the calling Player's History, using the same values as Win (1), Lose (0), and Draw (0.5), and recalculates the player's Rating, GamesPlayed, and PeakRating for the current rating period as described for Void. The same errors are returned as by Void. A MarginOfVictory result loses its Margin and is replayed as a HeadToHead result, as the points no longer match the score. The returned Outcome describes the change caused by the amendment. To amend the result for the opponent as well, call Opponent.Amend with the complementary score.
+func (p *Player) Amend(i int, score float64) (Outcome, error) {
+	if i < 0 || i >= len(p.History) {
+		return Outcome{}, ErrNoResult
+	}
+	history := make([]Result, len(p.History))
+	copy(history, p.History)
+	history[i].Score = score
+	history[i].Margin = 0
+	if history[i].Method == MarginOfVictory {
+		history[i].Method = HeadToHead
+	}
+	return p.recalculate(history)
+}
+
+// recalculate replaces the calling Player's History with the given results, replaying them one at a time from the player's Parameters so that any KFactorPolicy sees the same History it did when each result was first recorded.
+func (p *Player) recalculate(history []Result) (Outcome, error) {
+	for _, r := range p.History {
+		if r.Method == FreeForAll || r.Method == TeamMatch {
+			return Outcome{}, ErrNotReplayable
+		}
+	}
+	before := p.Rating
+	p.Reset()
+	for _, r := range history {
+		p.History = append(p.History, r)
+		rd := p.delta(r.Score, p.Rating+r.Advantage, r.Rating)
+		if r.Method == MarginOfVictory {
+			rd = p.delta(r.Score, p.Rating, r.Rating) * p.Config.Margin.multiplier(p.Rating-r.Rating, r.Margin)
+		}
+		p.apply(rd+p.Rating, 1)
+	}
+	return Outcome{Rating: p.Rating, RatingDelta: p.Rating - before}, nil
+}
@@ -0,0 +1,130 @@
+package elo
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestVoid(t *testing.T) {
+	c := DefaultConfig()
+	c.KFactorPolicy = FIDE{}
+	c.Advantage = 50
+	parameters := Parameters{InitialRating: 1500}
+	p := c.NewPlayer(parameters)
+	p.Win(1400)
+	p.Lose(1550)
+	p.Score(1450, 3, 1)
+	p.Play(1600, 0.5, 1)
+
+	// Voiding a result gives the same ratings as if it had never been recorded.
+	o, err := p.Void(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := c.NewPlayer(parameters)
+	q.Win(1400)
+	q.Score(1450, 3, 1)
+	q.Play(1600, 0.5, 1)
+	if p.Rating != q.Rating || p.GamesPlayed != 3 || p.PeakRating != q.PeakRating || len(p.History) != 3 {
+		t.Log(p, q)
+		t.Fail()
+	}
+	if o.Rating != p.Rating || o.RatingDelta <= 0 {
+		t.Log(o)
+		t.Fail()
+	}
+
+	for len(p.History) > 0 {
+		p.Void(0)
+	}
+	if p.Rating != 1500 || p.GamesPlayed != 0 || p.PeakRating != 1500 {
+		t.Log(p)
+		t.Fail()
+	}
+	if _, err := p.Void(0); err != ErrNoResult {
+		t.Log(err)
+		t.Fail()
+	}
+	if _, err := p.Amend(3, 1); err != ErrNoResult {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestAmend(t *testing.T) {
+	parameters := Parameters{InitialRating: 1500}
+	p := NewPlayer(parameters)
+	p.Win(1400)
+	p.Lose(1550)
+	p.Win(1450)
+	o, err := p.Amend(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := NewPlayer(parameters)
+	q.Win(1400)
+	q.Win(1550)
+	q.Win(1450)
+	if p.Rating != q.Rating || p.History[1].Score != 1 || o.RatingDelta <= 0 {
+		t.Log(p, q, o)
+		t.Fail()
+	}
+}
+
+func TestVoidScore(t *testing.T) {
+	// A drawn Score uses the margin multiplier for a draw even though its Margin is zero, and is replayed the same way.
+	p := NewPlayer(Parameters{InitialRating: 1500})
+	p.Score(1600, 2, 2)
+	p.Win(1500)
+	if _, err := p.Void(1); err != nil {
+		t.Fatal(err)
+	}
+	q := NewPlayer(Parameters{InitialRating: 1500})
+	q.Score(1600, 2, 2)
+	if p.Rating != q.Rating || math.Abs(p.Rating-1500-32*(0.5-1/(1+math.Pow(10, 0.25)))*math.Ln2) > 1e-9 || p.History[0].Method != MarginOfVictory {
+		t.Log(p, q)
+		t.Fail()
+	}
+
+	// An amended Score is replayed as a plain result.
+	if _, err := p.Amend(0, 1); err != nil {
+		t.Fatal(err)
+	}
+	q = NewPlayer(Parameters{InitialRating: 1500})
+	q.Win(1600)
+	if p.Rating != q.Rating || p.History[0].Method != HeadToHead {
+		t.Log(p, q)
+		t.Fail()
+	}
+}
+
+func TestVoidMultiplayer(t *testing.T) {
+	parameters := Parameters{InitialRating: 1500}
+	var placings []Placing
+	for i := 0; i < 4; i++ {
+		placings = append(placings, Placing{Player: NewPlayer(parameters), Rank: i})
+	}
+	RateFreeForAll(placings)
+	a, b := NewPlayer(parameters), NewPlayer(parameters)
+	RateTeams([]Member{{Player: a}}, []Member{{Player: b}}, 1, rating.Mean)
+	for _, p := range []*Player{placings[0].Player, a} {
+		p.Win(1500)
+		before := *p
+		before.History = append([]Result(nil), p.History...)
+		if _, err := p.Void(len(p.History) - 1); err != ErrNotReplayable {
+			t.Log(err)
+			t.Fail()
+		}
+		if _, err := p.Amend(0, 0); err != ErrNotReplayable {
+			t.Log(err)
+			t.Fail()
+		}
+		if !reflect.DeepEqual(*p, before) {
+			t.Log(*p, before)
+			t.Fail()
+		}
+	}
+}
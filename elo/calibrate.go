@@ -0,0 +1,40 @@
+package elo
+
+import (
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/league"
+	"github.com/dylrich/rating/internal/optimize"
+)
+
+// FitAdvantage returns a copy of the Config with Advantage chosen to minimise the mean log loss of predicting each game in a historical dataset. The games must be in chronological order and are replayed one rating period at a time using RatePeriod, with every player starting from the given Parameters. Each game is predicted from both players' ratings before its rating period. Only games with a non-zero Advantage carry information about it.
+func (c Config) FitAdvantage(p Parameters, games []rating.Game) Config {
+	c = c.withDefaults()
+	c.Advantage = optimize.Golden(func(x float64) float64 {
+		trial := c
+		trial.Advantage = x
+		return trial.logLoss(p, games)
+	}, -c.D, c.D, 0.01)
+	return c
+}
+
+func (c Config) logLoss(p Parameters, games []rating.Game) float64 {
+	return c.replay(p, games, func(a, b *Player, g rating.Game) float64 {
+		return rating.LogLoss(c.Expected(a.Rating+g.Advantage*c.Advantage, b.Rating), g.Score)
+	})
+}
+
+// replay rates the games one rating period at a time, and returns the mean of the loss of predicting each game from both players' ratings before its rating period.
+func (c Config) replay(p Parameters, games []rating.Game, loss func(a, b *Player, g rating.Game) float64) float64 {
+	if len(games) == 0 {
+		return 0
+	}
+	total := 0.0
+	league.Replay[*Player]{
+		NewPlayer: func() *Player { return c.NewPlayer(p) },
+		Predict: func(a, b *Player, g rating.Game) {
+			total += loss(a, b, g)
+		},
+		RatePeriod: ratePeriod,
+	}.Run(games)
+	return total / float64(len(games))
+}
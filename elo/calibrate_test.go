@@ -0,0 +1,37 @@
+package elo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating/internal/simulate"
+)
+
+func TestPlay(t *testing.T) {
+	league := Config{Advantage: 100}
+	home := league.NewPlayer(Parameters{InitialRating: 1500})
+	away := league.NewPlayer(Parameters{InitialRating: 1600})
+	ho := home.Play(1600, 1, 1)
+	ao := away.Play(1500, 0, -1)
+	if math.Abs(ho.RatingDelta-16) > 1e-9 || math.Abs(ao.RatingDelta+16) > 1e-9 || home.History[0].Advantage != 100 {
+		t.Log(ho, ao, home.History)
+		t.Fail()
+	}
+
+	a := league.NewPlayer(Parameters{InitialRating: 1500})
+	b := league.NewPlayer(Parameters{InitialRating: 1500})
+	RatePeriod(nil, []Match{{Player: a, Opponent: b, Score: 0.5, Advantage: -1}})
+	if a.Rating <= 1500 || math.Abs(a.Rating+b.Rating-3000) > 1e-9 {
+		t.Log(a, b)
+		t.Fail()
+	}
+}
+
+func TestFitAdvantage(t *testing.T) {
+	games := simulate.League{Players: 30, Periods: 40, GamesPerPeriod: 40, Drift: 10, Advantage: 60}.Games(1)
+	fitted := DefaultConfig().FitAdvantage(Parameters{InitialRating: DefaultInitialRating}, games)
+	if math.Abs(fitted.Advantage-60) > 30 || fitted.KFactor != DefaultKFactor {
+		t.Log(fitted)
+		t.Fail()
+	}
+}
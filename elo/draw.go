@@ -0,0 +1,78 @@
+package elo
+
+import (
+	"math"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/optimize"
+)
+
+// DrawModel predicts the separate chances of a win, a draw, and a loss for a player whose rating exceeds their opponent's by the given difference, where scale is the Config's D.
+type DrawModel interface {
+	Probabilities(difference, scale float64) rating.Probabilities
+}
+
+// Davidson is Davidson's extension of the Bradley-Terry model to draws. The chance of a draw is proportional to the geometric mean of the chances of a win and a loss, and Draw (ν) sets the constant of proportionality, so the chance of a draw between evenly matched players is ν / (2 + ν). Draws are most likely between evenly matched players, and become rarer as the rating difference grows. Used as a Config.DrawModel, it gives the Elo-Davidson rating system.
+type Davidson struct {
+	Draw float64
+}
+
+// DrawMargin is the draw margin model of Rao and Kupper, the logistic counterpart of the draw margin used by TrueSkill. A player wins if their performance exceeds their opponent's by more than Margin rating points, loses if it falls short by more than Margin, and draws otherwise.
+type DrawMargin struct {
+	Margin float64
+}
+
+// Probabilities returns the chances of a win, a draw, and a loss under Davidson's model.
+func (m Davidson) Probabilities(difference, scale float64) rating.Probabilities {
+	win := math.Pow(10, difference/(2*scale))
+	loss := 1 / win
+	total := win + loss + m.Draw
+	return rating.Probabilities{Win: win / total, Draw: m.Draw / total, Loss: loss / total}
+}
+
+// Probabilities returns the chances of a win, a draw, and a loss under the draw margin model.
+func (m DrawMargin) Probabilities(difference, scale float64) rating.Probabilities {
+	win := 1 / (1 + math.Pow(10, -(difference-m.Margin)/scale))
+	loss := 1 / (1 + math.Pow(10, (difference+m.Margin)/scale))
+	return rating.Probabilities{Win: win, Draw: 1 - win - loss, Loss: loss}
+}
+
+// Probabilities returns the separate chances of a win, a draw, and a loss for a player with the given rating against an opponent, using the Config's DrawModel. Without a DrawModel, the chance of a draw is zero and the chance of a win is the Elo expected score. Any advantage held by either side should be added to that side's rating by the caller.
+func (c Config) Probabilities(playerRating, opponentRating float64) rating.Probabilities {
+	c = c.withDefaults()
+	if c.DrawModel == nil {
+		expected := expectation(c.transform(playerRating), c.transform(opponentRating))
+		return rating.Probabilities{Win: expected, Loss: 1 - expected}
+	}
+	return c.DrawModel.Probabilities(playerRating-opponentRating, c.D)
+}
+
+// Probabilities returns the calling Player's chances of a win, a draw, and a loss against an opponent with the given rating, using the Player's Config.
+func (p *Player) Probabilities(opponentRating float64) rating.Probabilities {
+	return p.Config.Probabilities(p.Rating, opponentRating)
+}
+
+// FitDraw returns a copy of the Config with the parameter of its DrawModel chosen to minimise the mean log loss of predicting the win, draw, or loss in each game of a historical dataset, replayed as described for FitAdvantage. The DrawModel must be a Davidson or DrawMargin, and a Davidson model is used if it is nil. Other DrawModels are left unchanged. The games' scores must be 0, 0.5, or 1.
+func (c Config) FitDraw(p Parameters, games []rating.Game) Config {
+	c = c.withDefaults()
+	var model func(x float64) DrawModel
+	var hi float64
+	switch c.DrawModel.(type) {
+	case nil, Davidson:
+		model = func(x float64) DrawModel { return Davidson{Draw: x} }
+		hi = 10
+	case DrawMargin:
+		model = func(x float64) DrawModel { return DrawMargin{Margin: x} }
+		hi = c.D
+	default:
+		return c
+	}
+	c.DrawModel = model(optimize.Golden(func(x float64) float64 {
+		trial := c
+		trial.DrawModel = model(x)
+		return trial.replay(p, games, func(a, b *Player, g rating.Game) float64 {
+			return trial.Probabilities(a.Rating+g.Advantage*trial.Advantage, b.Rating).LogLoss(g.Score)
+		})
+	}, 0, hi, 0.0001))
+	return c
+}
@@ -0,0 +1,112 @@
+package elo
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestDavidson(t *testing.T) {
+	p := Davidson{Draw: 1}.Probabilities(0, DefaultD)
+	if math.Abs(p.Win-1.0/3) > 0.000001 || math.Abs(p.Draw-1.0/3) > 0.000001 || math.Abs(p.Loss-1.0/3) > 0.000001 {
+		t.Log(p)
+		t.Fail()
+	}
+
+	// The win and loss probabilities keep the Elo odds of ten to one for a 400 point difference.
+	p = Davidson{Draw: 1}.Probabilities(400, DefaultD)
+	if math.Abs(p.Win/p.Loss-10) > 0.000001 || math.Abs(p.Win+p.Draw+p.Loss-1) > 0.000001 {
+		t.Log(p)
+		t.Fail()
+	}
+	if p := (Davidson{}).Probabilities(400, DefaultD); math.Abs(p.Win-10.0/11) > 0.000001 || p.Draw != 0 {
+		t.Log(p)
+		t.Fail()
+	}
+}
+
+func TestDrawMargin(t *testing.T) {
+	p := DrawMargin{Margin: 100}.Probabilities(0, DefaultD)
+	if math.Abs(p.Win-1/(1+math.Pow(10, 0.25))) > 0.000001 || p.Win != p.Loss || math.Abs(p.Win+p.Draw+p.Loss-1) > 0.000001 {
+		t.Log(p)
+		t.Fail()
+	}
+	if far := (DrawMargin{Margin: 100}).Probabilities(800, DefaultD); far.Draw >= p.Draw || far.Win <= p.Win {
+		t.Log(p, far)
+		t.Fail()
+	}
+}
+
+func TestEloDavidson(t *testing.T) {
+	c := DefaultConfig()
+	c.DrawModel = Davidson{Draw: 1}
+	if p := c.Probabilities(1500, 1500); math.Abs(p.Draw-1.0/3) > 0.000001 {
+		t.Log(p)
+		t.Fail()
+	}
+
+	// Between evenly matched players, Elo-Davidson behaves like Elo.
+	p := c.NewPlayer(Parameters{InitialRating: 1500})
+	if o := p.Win(1500); math.Abs(o.RatingDelta-16) > 0.000001 {
+		t.Log(o)
+		t.Fail()
+	}
+
+	// The favourite's expected score is lower than under Elo, because some of its wins become draws.
+	p = c.NewPlayer(Parameters{InitialRating: 1900})
+	elo := DefaultConfig().Expected(1900, 1500)
+	if e := p.Probabilities(1500).Expected(); e >= elo || e <= 0.5 {
+		t.Log(e, elo)
+		t.Fail()
+	}
+
+	// A draw therefore costs the favourite fewer points.
+	if o := p.Draw(1500); o.RatingDelta <= DefaultConfig().NewPlayer(Parameters{InitialRating: 1900}).Draw(1500).RatingDelta {
+		t.Log(o)
+		t.Fail()
+	}
+}
+
+func drawGames(model DrawModel, n int, seed int64) []rating.Game {
+	r := rand.New(rand.NewSource(seed))
+	skills := make([]float64, 20)
+	for i := range skills {
+		skills[i] = 1500 + r.NormFloat64()*100
+	}
+	games := make([]rating.Game, 0, n)
+	for i := 0; i < n; i++ {
+		a, b := r.Intn(len(skills)), r.Intn(len(skills))
+		if a == b {
+			continue
+		}
+		p := model.Probabilities(skills[a]-skills[b], DefaultD)
+		score := 0.0
+		if x := r.Float64(); x < p.Win {
+			score = 1
+		} else if x < p.Win+p.Draw {
+			score = 0.5
+		}
+		games = append(games, rating.Game{Period: i / 100, Player: strconv.Itoa(a), Opponent: strconv.Itoa(b), Score: score})
+	}
+	return games
+}
+
+func TestFitDraw(t *testing.T) {
+	params := Parameters{InitialRating: 1500}
+	c := DefaultConfig().FitDraw(params, drawGames(Davidson{Draw: 0.8}, 5000, 1))
+	if m, ok := c.DrawModel.(Davidson); !ok || math.Abs(m.Draw-0.8) > 0.1 {
+		t.Log(c.DrawModel)
+		t.Fail()
+	}
+
+	c = DefaultConfig()
+	c.DrawModel = DrawMargin{}
+	c = c.FitDraw(params, drawGames(DrawMargin{Margin: 60}, 5000, 1))
+	if m, ok := c.DrawModel.(DrawMargin); !ok || math.Abs(m.Margin-60) > 10 {
+		t.Log(c.DrawModel)
+		t.Fail()
+	}
+}
@@ -8,103 +8,206 @@ const (
 
 	// DefaultInitialRating is the standard value for an initial rating for players that have no result history from the previous rating period.
 	DefaultInitialRating = 1500
+
+	// DefaultKFactor is the standard value for Config.KFactor.
+	DefaultKFactor = 32.0
+
+	// DefaultD is the standard value for Config.D.
+	DefaultD = 400.0
 )
 
-var (
+// Config contains the system-wide settings for a league. It is passed to each Player on construction, so leagues with different settings can be rated side by side in the same process. Any zero field is replaced with its package default when the Config is used to create a Player.
+type Config struct {
 	// KFactor regulates how much new results impact the player's rating.
-	KFactor = 32.0
+	KFactor float64
 
-	// D represents the Elo standard deviation value
-	D = 400.0
-)
+	// D represents the Elo standard deviation value, which sets the scale of the ratings. A difference of D points means the higher rated player is expected to score ten times as often as the lower rated one.
+	D float64
+
+	// KFactorPolicy, if set, decides the KFactor for each result based on the player's state instead of using the fixed KFactor.
+	KFactorPolicy KFactorPolicy
+
+	// Margin contains the settings for results recorded with Player.Score, which take the margin of victory into account.
+	Margin Margin
 
-// Player represents an individual participant in the competition. The Player struct contains the Rating measure, which is the Elo system's estimation of how skilled that player is. This is a moment-in-time snapshot, and will be updated on any new results for that player. The Parameters attribute contains initial values for that player which can be used to reconstruct the player's current rating from scratch when combined with the History data. Parameters should be altered at the beginning of a new rating period to be the final Rating from the previous period.
+	// Advantage is the number of rating points by which the side with home field, the first move, or a similar edge is expected to outperform its rating in this league. It is applied to matches recorded with Player.Play or Match.Advantage, and can be estimated from historical data with Config.FitAdvantage.
+	Advantage float64
+
+	// DrawModel, if set, predicts the chance of a draw separately from the chances of a win and a loss. The expected score used to update ratings then counts each predicted draw as half a win, which with a Davidson model gives the Elo-Davidson rating system. Its parameter can be estimated from historical data with Config.FitDraw.
+	DrawModel DrawModel
+}
+
+// Player represents an individual participant in the competition. The Player struct contains the Rating measure, which is the Elo system's estimation of how skilled that player is, along with the number of games the player has completed and the highest Rating they have reached. These are moment-in-time snapshots, and will be updated on any new results for that player. The Parameters attribute contains initial values for that player which can be used to reconstruct the player's current rating from scratch when combined with the History data. Parameters should be altered at the beginning of a new rating period to be the final Rating from the previous period.
 type Player struct {
-	Rating     float64
-	History    []Result
-	Parameters Parameters
+	Rating      float64
+	GamesPlayed int
+	PeakRating  float64
+	History     []Result
+	Parameters  Parameters
+	Config      Config
 }
 
-// Parameters contains initial Rating for a player. This is set on instantiation of the player.
+// Parameters contains initial values for a player. These are set on instantiation of the player, and can be altered later by using the Player.NewPeriod() method. InitialGamesPlayed and InitialPeakRating allow players with an existing record to be carried over from elsewhere.
 type Parameters struct {
-	InitialRating float64
+	InitialRating      float64
+	InitialGamesPlayed int
+	InitialPeakRating  float64
 }
 
-// Result contains the important information from a match that has occurred. The information is used to calculate new ratings when new results are added.
+// Result contains the important information from a match that has occurred. The information is used to calculate new ratings when new results are added. Method records how the result was applied to the player's Rating.
 type Result struct {
-	Rating, Score float64
+	Rating, Score, Margin, Advantage float64
+	Method                           Method
 }
 
+// Method is the way a Result was applied to a player's Rating.
+type Method int
+
+const (
+
+	// HeadToHead is a result from Win, Lose, Draw, Play, or RatePeriod.
+	HeadToHead Method = iota
+
+	// MarginOfVictory is a result from Score, whose rating change was scaled by its Margin.
+	MarginOfVictory
+
+	// FreeForAll is one of the pairwise results from RateFreeForAll.
+	FreeForAll
+
+	// TeamMatch is a result from RateTeams, recorded against the opposing team's composite rating.
+	TeamMatch
+)
+
 // Outcome is a snapshot of the current state for a player, including the delta value for this result's Rating change. This information can be passed to users to give them an idea of how much the most recent result has impacted their ranking criteria.
 type Outcome struct {
 	Rating, RatingDelta float64
 }
 
-// NewPlayer is used to instantiate a new Player object based on the input parameters. If any of the parameters are nil, they will be automatically populated with the default values.
+// DefaultConfig returns a Config populated with the package default values.
+func DefaultConfig() Config {
+	return Config{KFactor: DefaultKFactor, D: DefaultD}
+}
+
+// NewPlayer is used to instantiate a new Player object based on the input parameters, using DefaultConfig for the system settings. If any of the parameters are nil, they will be automatically populated with the default values.
 func NewPlayer(p Parameters) *Player {
+	return DefaultConfig().NewPlayer(p)
+}
+
+// NewPlayer is used to instantiate a new Player object that is rated using the calling Config. If any of the parameters are nil, they will be automatically populated with the default values.
+func (c Config) NewPlayer(p Parameters) *Player {
 	if &p.InitialRating == nil {
 		p.InitialRating = DefaultInitialRating
 	}
-	return &Player{Rating: p.InitialRating, Parameters: p}
+	p.InitialPeakRating = math.Max(p.InitialPeakRating, p.InitialRating)
+	return &Player{
+		Rating:      p.InitialRating,
+		GamesPlayed: p.InitialGamesPlayed,
+		PeakRating:  p.InitialPeakRating,
+		Parameters:  p,
+		Config:      c.withDefaults(),
+	}
 }
 
 // Win is called when a player has won a match against another player, earning an Elo score of 1. This function will handle updating the calling Player only. To add the loss to the opponent's rating, call Opponent.Lose(Player) as appropriate.
 func (p *Player) Win(opponentRating float64) *Outcome {
-	p.addResult(1, opponentRating)
-	outcome := p.getOutcome(1, opponentRating)
-	p.Rating = outcome.Rating
+	p.addResult(1, opponentRating, 0, HeadToHead)
+	outcome := p.getOutcome(1, opponentRating, 0)
+	p.apply(outcome.Rating, 1)
 	return &outcome
 }
 
 // Lose is called when a player has won a match against another player, earning an Elo score of 0. This function will handle updating the calling Player only. To add the loss to the opponent's rating, call Opponent.Lose(Player) as appropriate.
 func (p *Player) Lose(opponentRating float64) *Outcome {
-	p.addResult(0, opponentRating)
-	outcome := p.getOutcome(0, opponentRating)
-	p.Rating = outcome.Rating
+	p.addResult(0, opponentRating, 0, HeadToHead)
+	outcome := p.getOutcome(0, opponentRating, 0)
+	p.apply(outcome.Rating, 1)
 	return &outcome
 }
 
 // Draw is called when a player has won a match against another player, earning an Elo score of 0.5. This function will handle updating the calling Player only. To add the draw record to the opponent's rating, call Opponent.Draw(Player) as appropriate.
 func (p *Player) Draw(opponentRating float64) *Outcome {
-	p.addResult(0.5, opponentRating)
-	outcome := p.getOutcome(0.5, opponentRating)
-	p.Rating = outcome.Rating
+	p.addResult(0.5, opponentRating, 0, HeadToHead)
+	outcome := p.getOutcome(0.5, opponentRating, 0)
+	p.apply(outcome.Rating, 1)
 	return &outcome
 }
 
-// Reset will wipe the calling Player's history completely, and revert the current Rating to its initial value.
+// Play is called when a player has completed a match against another player in which one side may hold an advantage such as home field or the first move. The score uses the same values as Win (1), Lose (0), and Draw (0.5). The advantage is the share of Config.Advantage held by the player: 1 when the player holds it, -1 when the opponent holds it, and 0 at a neutral venue. This function will handle updating the calling Player only. To add the result to the opponent's rating, call Opponent.Play with the complementary score and the advantage negated.
+func (p *Player) Play(opponentRating, score, advantage float64) *Outcome {
+	p.addResult(score, opponentRating, advantage*p.Config.Advantage, HeadToHead)
+	outcome := p.getOutcome(score, opponentRating, advantage*p.Config.Advantage)
+	p.apply(outcome.Rating, 1)
+	return &outcome
+}
+
+// Reset will wipe the calling Player's history completely, and revert the current Rating, GamesPlayed, and PeakRating to their initial values.
 func (p *Player) Reset() {
 	p.History = []Result{}
 	p.Rating = p.Parameters.InitialRating
+	p.GamesPlayed = p.Parameters.InitialGamesPlayed
+	p.PeakRating = p.Parameters.InitialPeakRating
 }
 
-// NewPeriod takes the calling Player's current Rating and sets it as the new initital rating before resetting the player's history to empty.
+// NewPeriod takes the calling Player's current Rating, GamesPlayed, and PeakRating and sets them as the new initital values before resetting the player's history to empty.
 func (p *Player) NewPeriod() {
 	p.Parameters.InitialRating = p.Rating
+	p.Parameters.InitialGamesPlayed = p.GamesPlayed
+	p.Parameters.InitialPeakRating = p.PeakRating
 	p.Reset()
 }
 
-func (p *Player) getOutcome(score, opponentRating float64) Outcome {
-	rd := ratingDelta(score, expectation(transform(p.Rating), transform(opponentRating)))
+func (p *Player) getOutcome(score, opponentRating, advantage float64) Outcome {
+	rd := p.delta(score, p.Rating+advantage, opponentRating)
 	return Outcome{
 		Rating:      rd + p.Rating,
 		RatingDelta: rd,
 	}
 }
 
-func (p *Player) addResult(score, rating float64) {
+func (p *Player) delta(score, rating, opponentRating float64) float64 {
+	c := p.Config
+	return ratingDelta(p.kFactor(), score, c.Expected(rating, opponentRating))
+}
+
+func (p *Player) kFactor() float64 {
+	if p.Config.KFactorPolicy != nil {
+		return p.Config.KFactorPolicy.KFactor(p)
+	}
+	return p.Config.KFactor
+}
+
+func (p *Player) apply(rating float64, games int) {
+	p.Rating = rating
+	p.GamesPlayed += games
+	p.PeakRating = math.Max(p.PeakRating, rating)
+}
+
+func (p *Player) addResult(score, rating, advantage float64, method Method) {
 	var r Result
 	r.Rating = rating
 	r.Score = score
+	r.Advantage = advantage
+	r.Method = method
 	p.History = append(p.History, r)
 }
 
-func ratingDelta(score, expectation float64) float64 {
-	return KFactor * (score - expectation)
+func (c Config) withDefaults() Config {
+	if c.KFactor == 0 {
+		c.KFactor = DefaultKFactor
+	}
+	if c.D == 0 {
+		c.D = DefaultD
+	}
+	c.Margin = c.Margin.withDefaults()
+	return c
+}
+
+func ratingDelta(kFactor, score, expectation float64) float64 {
+	return kFactor * (score - expectation)
 }
 
-func transform(rating float64) float64 {
-	return math.Pow(10, (rating / D))
+func (c Config) transform(rating float64) float64 {
+	return math.Pow(10, (rating / c.D))
 }
 
 func expectation(t1, t2 float64) float64 {
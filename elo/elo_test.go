@@ -23,3 +23,19 @@ func TestElo(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestConfig(t *testing.T) {
+	league := Config{KFactor: 16}
+	a := league.NewPlayer(Parameters{InitialRating: 1500})
+	b := NewPlayer(Parameters{InitialRating: 1500})
+	oa := a.Win(1500)
+	ob := b.Win(1500)
+	if math.Abs(oa.RatingDelta-8) > 1e-9 || math.Abs(ob.RatingDelta-16) > 1e-9 {
+		t.Log(oa, ob)
+		t.Fail()
+	}
+	if a.Config.D != DefaultD {
+		t.Log(a.Config)
+		t.Fail()
+	}
+}
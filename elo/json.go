@@ -0,0 +1,79 @@
+package elo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// configJSON is the encoded form of a Config, with its interface fields replaced by the name or contents of their concrete type.
+type configJSON struct {
+	KFactor, D    float64
+	KFactorPolicy *kFactorPolicyJSON `json:",omitempty"`
+	Margin        Margin
+	Advantage     float64
+	DrawModel     *drawModelJSON `json:",omitempty"`
+}
+
+type kFactorPolicyJSON struct {
+	FIDE *FIDE `json:",omitempty"`
+	USCF *USCF `json:",omitempty"`
+}
+
+type drawModelJSON struct {
+	Davidson   *Davidson   `json:",omitempty"`
+	DrawMargin *DrawMargin `json:",omitempty"`
+}
+
+// MarshalJSON encodes the Config as JSON, so that Players can be stored and restored without losing their settings. The FIDE and USCF KFactorPolicies and the Davidson and DrawMargin DrawModels are encoded along with their parameters. Any other KFactorPolicy or DrawModel, including a KFactorFunc, cannot be encoded and returns an error.
+func (c Config) MarshalJSON() ([]byte, error) {
+	j := configJSON{KFactor: c.KFactor, D: c.D, Margin: c.Margin, Advantage: c.Advantage}
+	switch p := c.KFactorPolicy.(type) {
+	case nil:
+	case FIDE:
+		j.KFactorPolicy = &kFactorPolicyJSON{FIDE: &p}
+	case USCF:
+		j.KFactorPolicy = &kFactorPolicyJSON{USCF: &p}
+	default:
+		return nil, fmt.Errorf("elo: cannot encode KFactorPolicy of type %T", p)
+	}
+	switch m := c.DrawModel.(type) {
+	case nil:
+	case Davidson:
+		j.DrawModel = &drawModelJSON{Davidson: &m}
+	case DrawMargin:
+		j.DrawModel = &drawModelJSON{DrawMargin: &m}
+	default:
+		return nil, fmt.Errorf("elo: cannot encode DrawModel of type %T", m)
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes a Config encoded by MarshalJSON.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	var j configJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	decoded := Config{KFactor: j.KFactor, D: j.D, Margin: j.Margin, Advantage: j.Advantage}
+	if j.KFactorPolicy != nil {
+		switch {
+		case j.KFactorPolicy.FIDE != nil:
+			decoded.KFactorPolicy = *j.KFactorPolicy.FIDE
+		case j.KFactorPolicy.USCF != nil:
+			decoded.KFactorPolicy = *j.KFactorPolicy.USCF
+		default:
+			return errors.New("elo: unknown KFactorPolicy")
+		}
+	}
+	if j.DrawModel != nil {
+		switch {
+		case j.DrawModel.Davidson != nil:
+			decoded.DrawModel = *j.DrawModel.Davidson
+		case j.DrawModel.DrawMargin != nil:
+			decoded.DrawModel = *j.DrawModel.DrawMargin
+		}
+	}
+	*c = decoded
+	return nil
+}
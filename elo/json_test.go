@@ -0,0 +1,41 @@
+package elo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	for _, c := range []Config{
+		DefaultConfig(),
+		{KFactor: 24, D: 400, KFactorPolicy: FIDE{}, Margin: Margin{Autocorrelation: 2.2, RatingPerPoint: 25}, Advantage: 35.5, DrawModel: Davidson{Draw: 0.8}},
+		{KFactorPolicy: USCF{}, DrawModel: DrawMargin{Margin: 60}},
+		{KFactorPolicy: USCF{MinEffectiveGames: 1}},
+	} {
+		p := c.NewPlayer(Parameters{InitialRating: 1500, InitialGamesPlayed: 3, InitialPeakRating: 1520})
+		p.Win(1400)
+		p.Play(1600, 0.5, 1)
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded Player
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*p, decoded) {
+			t.Log(*p, decoded)
+			t.Fail()
+		}
+	}
+
+	c := DefaultConfig()
+	c.KFactorPolicy = KFactorFunc(func(p *Player) float64 { return 10 })
+	if _, err := json.Marshal(c); err == nil {
+		t.Fail()
+	}
+	if err := json.Unmarshal([]byte(`{"KFactorPolicy":{"ECF":{}}}`), &c); err == nil {
+		t.Fail()
+	}
+}
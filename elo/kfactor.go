@@ -0,0 +1,58 @@
+package elo
+
+import (
+	"math"
+)
+
+// DefaultMinEffectiveGames is the standard value for USCF.MinEffectiveGames.
+const DefaultMinEffectiveGames = 4.0
+
+// KFactorPolicy decides the KFactor used to rate a player's most recent result. KFactor is called after the result has been added to the player's History, but before the player's Rating, GamesPlayed, and PeakRating have been updated for it.
+type KFactorPolicy interface {
+	KFactor(p *Player) float64
+}
+
+// KFactorFunc allows an ordinary function of the player's state to be used as a KFactorPolicy.
+type KFactorFunc func(p *Player) float64
+
+// FIDE is the KFactorPolicy used by FIDE. A player's KFactor is 40 until they have completed 30 games, 10 once their rating has reached 2400 at any point, and 20 otherwise. FIDE's additional rule for juniors is not modelled, as the Player does not track age.
+type FIDE struct{}
+
+// USCF is the KFactorPolicy used by the US Chess Federation, K = 800 / (Ne + m). The effective number of games Ne is the number of games the player had completed before the current rating period, capped by a function of their pre-period rating, and m is the number of games in the player's History for the current rating period. The rating period therefore plays the role of a USCF event, and events should be rated with RatePeriod, which records every game before consulting the policy so that all games in the event use the same K. When results are rated one at a time with Win, Lose, Draw, Play, or Score, m only counts the games played so far, so K falls over the course of the period. Ne is never taken to be below MinEffectiveGames, so that a brand-new player's first game does not move their rating by up to 800 points.
+type USCF struct {
+	MinEffectiveGames float64
+}
+
+// KFactor calls f(p).
+func (f KFactorFunc) KFactor(p *Player) float64 {
+	return f(p)
+}
+
+// KFactor returns the FIDE KFactor for the player.
+func (FIDE) KFactor(p *Player) float64 {
+	if p.GamesPlayed < 30 {
+		return 40
+	}
+	if p.PeakRating >= 2400 {
+		return 10
+	}
+	return 20
+}
+
+// KFactor returns the USCF KFactor for the player. If MinEffectiveGames is zero, DefaultMinEffectiveGames is used.
+func (u USCF) KFactor(p *Player) float64 {
+	floor := u.MinEffectiveGames
+	if floor == 0 {
+		floor = DefaultMinEffectiveGames
+	}
+	m := math.Max(float64(len(p.History)), 1)
+	return 800 / (math.Max(effectiveGames(p.Parameters.InitialRating, p.Parameters.InitialGamesPlayed), floor) + m)
+}
+
+func effectiveGames(rating float64, games int) float64 {
+	limit := 50.0
+	if rating <= 2355 {
+		limit = 50 / math.Sqrt(0.662+0.00000739*math.Pow(2569-rating, 2))
+	}
+	return math.Min(float64(games), limit)
+}
@@ -0,0 +1,93 @@
+package elo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFIDE(t *testing.T) {
+	fide := Config{KFactorPolicy: FIDE{}}
+	p := fide.NewPlayer(Parameters{InitialRating: 1500})
+	if o := p.Win(1500); math.Abs(o.RatingDelta-20) > 1e-9 || p.GamesPlayed != 1 {
+		t.Log(o, p.GamesPlayed)
+		t.Fail()
+	}
+
+	p = fide.NewPlayer(Parameters{InitialRating: 2000, InitialGamesPlayed: 30})
+	if k := p.kFactor(); k != 20 {
+		t.Log(k)
+		t.Fail()
+	}
+
+	p = fide.NewPlayer(Parameters{InitialRating: 2390, InitialGamesPlayed: 100})
+	p.Win(2390)
+	if p.PeakRating < 2400 {
+		t.Log(p.PeakRating)
+		t.Fail()
+	}
+	p.Lose(2390)
+	p.NewPeriod()
+	if k := p.kFactor(); k != 10 || p.Rating >= 2400 || p.Parameters.InitialPeakRating != p.PeakRating {
+		t.Log(k, p)
+		t.Fail()
+	}
+}
+
+func TestUSCF(t *testing.T) {
+	uscf := Config{KFactorPolicy: USCF{}}
+	p := uscf.NewPlayer(Parameters{InitialRating: 1500, InitialGamesPlayed: 100})
+	p.addResult(1, 1500, 0, HeadToHead)
+	if k := p.kFactor(); math.Abs(k-45.53) > .01 {
+		t.Log(k)
+		t.Fail()
+	}
+
+	p = uscf.NewPlayer(Parameters{InitialRating: 2400, InitialGamesPlayed: 100})
+	p.addResult(1, 2400, 0, HeadToHead)
+	p.addResult(1, 2400, 0, HeadToHead)
+	if k := p.kFactor(); math.Abs(k-800.0/52) > 1e-9 {
+		t.Log(k)
+		t.Fail()
+	}
+
+	// A brand-new player's K is limited by MinEffectiveGames, and is the same for every game in a period rated with RatePeriod.
+	p = uscf.NewPlayer(Parameters{InitialRating: 1500})
+	if o := p.Win(1500); math.Abs(o.RatingDelta-800.0/5/2) > 1e-9 {
+		t.Log(o)
+		t.Fail()
+	}
+	p = uscf.NewPlayer(Parameters{InitialRating: 1500})
+	var matches []Match
+	for i := 0; i < 4; i++ {
+		matches = append(matches, Match{Player: p, Opponent: uscf.NewPlayer(Parameters{InitialRating: 1500, InitialGamesPlayed: 100}), Score: 1})
+	}
+	if o := RatePeriod(nil, matches)[p]; math.Abs(o.RatingDelta-4*800.0/8/2) > 1e-9 {
+		t.Log(o)
+		t.Fail()
+	}
+	p = Config{KFactorPolicy: USCF{MinEffectiveGames: 1}}.NewPlayer(Parameters{InitialRating: 1500})
+	if o := p.Win(1500); math.Abs(o.RatingDelta-800.0/2/2) > 1e-9 {
+		t.Log(o)
+		t.Fail()
+	}
+}
+
+func TestKFactorFunc(t *testing.T) {
+	provisional := Config{KFactorPolicy: KFactorFunc(func(p *Player) float64 {
+		if p.GamesPlayed < 2 {
+			return 64
+		}
+		return 16
+	})}
+	p := provisional.NewPlayer(Parameters{InitialRating: 1500})
+	deltas := []float64{p.Win(1500).RatingDelta, p.Win(1500).RatingDelta, p.Win(1500).RatingDelta}
+	if deltas[0] != 32 || deltas[1] <= 16 || deltas[2] >= 8 {
+		t.Log(deltas)
+		t.Fail()
+	}
+	p.Reset()
+	if p.GamesPlayed != 0 || p.PeakRating != 1500 {
+		t.Log(p)
+		t.Fail()
+	}
+}
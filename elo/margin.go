@@ -0,0 +1,66 @@
+package elo
+
+import (
+	"math"
+)
+
+const (
+
+	// DefaultAutocorrelation is the standard value for Margin.Autocorrelation, as used by FiveThirtyEight's NFL model.
+	DefaultAutocorrelation = 2.2
+
+	// DefaultRatingPerPoint is the standard value for Margin.RatingPerPoint, as used by FiveThirtyEight's NFL model. FiveThirtyEight uses 28 for the NBA.
+	DefaultRatingPerPoint = 25.0
+)
+
+// Margin contains the settings for margin-of-victory aware updates. Any zero field is replaced with its package default when the Config is used to create a Player.
+type Margin struct {
+
+	// Autocorrelation corrects for favourites winning by larger margins than underdogs, which would otherwise inflate the ratings of strong players over time. The margin multiplier of a winner rated d points above the loser is scaled by Autocorrelation / (0.001d + Autocorrelation).
+	Autocorrelation float64
+
+	// RatingPerPoint is the rating difference that corresponds to one point of expected margin of victory.
+	RatingPerPoint float64
+}
+
+// Score is called when a player has completed a match against another player in which they scored points and their opponent scored opponentPoints. The result is a win, loss, or draw depending on the points, and the rating change is scaled by a multiplier of ln(|margin| + 1) with FiveThirtyEight's autocorrelation correction, so that larger margins of victory move ratings further. Draws use a margin of 1. This function will handle updating the calling Player only. To add the result to the opponent's rating, call Opponent.Score with the points reversed.
+func (p *Player) Score(opponentRating, points, opponentPoints float64) *Outcome {
+	score := 0.5
+	if points > opponentPoints {
+		score = 1
+	} else if points < opponentPoints {
+		score = 0
+	}
+	margin := points - opponentPoints
+	p.addResult(score, opponentRating, 0, MarginOfVictory)
+	p.History[len(p.History)-1].Margin = margin
+	rd := p.delta(score, p.Rating, opponentRating) * p.Config.Margin.multiplier(p.Rating-opponentRating, margin)
+	outcome := Outcome{Rating: rd + p.Rating, RatingDelta: rd}
+	p.apply(outcome.Rating, 1)
+	return &outcome
+}
+
+// PredictSpread returns the margin of victory that a player rated rating is expected to achieve against a player rated opponentRating. A negative spread means the player is expected to lose.
+func (c Config) PredictSpread(rating, opponentRating float64) float64 {
+	return (rating - opponentRating) / c.withDefaults().Margin.RatingPerPoint
+}
+
+func (m Margin) withDefaults() Margin {
+	if m.Autocorrelation == 0 {
+		m.Autocorrelation = DefaultAutocorrelation
+	}
+	if m.RatingPerPoint == 0 {
+		m.RatingPerPoint = DefaultRatingPerPoint
+	}
+	return m
+}
+
+func (m Margin) multiplier(ratingDifference, margin float64) float64 {
+	if margin == 0 {
+		return math.Ln2
+	}
+	if margin < 0 {
+		ratingDifference = -ratingDifference
+	}
+	return math.Log(math.Abs(margin)+1) * m.Autocorrelation / (0.001*ratingDifference + m.Autocorrelation)
+}
@@ -0,0 +1,49 @@
+package elo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	favourite := NewPlayer(Parameters{InitialRating: 1600})
+	underdog := NewPlayer(Parameters{InitialRating: 1500})
+	fo := favourite.Score(1500, 24, 10)
+	uo := underdog.Score(1600, 10, 24)
+	multiplier := math.Log(15) * 2.2 / (0.1 + 2.2)
+	expected := 32 * (1 - 1/(1+math.Pow(10, -0.25))) * multiplier
+	if math.Abs(fo.RatingDelta-expected) > 1e-9 || math.Abs(uo.RatingDelta+expected) > 1e-9 {
+		t.Log(fo, uo, expected)
+		t.Fail()
+	}
+	if favourite.History[0].Margin != 14 || underdog.History[0].Score != 0 || favourite.GamesPlayed != 1 {
+		t.Log(favourite.History, underdog.History)
+		t.Fail()
+	}
+
+	a := NewPlayer(Parameters{InitialRating: 1500})
+	b := NewPlayer(Parameters{InitialRating: 1500})
+	narrow := a.Score(1500, 21, 20)
+	wide := b.Score(1500, 41, 20)
+	if narrow.RatingDelta >= wide.RatingDelta {
+		t.Log(narrow, wide)
+		t.Fail()
+	}
+
+	c := NewPlayer(Parameters{InitialRating: 1500})
+	if draw := c.Score(1600, 17, 17); math.Abs(draw.RatingDelta-32*(0.5-1/(1+math.Pow(10, 0.25)))*math.Ln2) > 1e-9 {
+		t.Log(draw)
+		t.Fail()
+	}
+}
+
+func TestPredictSpread(t *testing.T) {
+	if s := DefaultConfig().PredictSpread(1600, 1500); s != 4 {
+		t.Log(s)
+		t.Fail()
+	}
+	if s := (Config{Margin: Margin{RatingPerPoint: 28}}).PredictSpread(1472, 1500); s != -1 {
+		t.Log(s)
+		t.Fail()
+	}
+}
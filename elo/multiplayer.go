@@ -0,0 +1,54 @@
+package elo
+
+// Placing is a participant's finishing position in a free-for-all match. Players with a lower Rank finished ahead of players with a higher Rank, and players with equal Ranks tied.
+type Placing struct {
+	Player *Player
+	Rank   int
+}
+
+// RateFreeForAll rates a free-for-all match between any number of players from their finishing order. The match is split into a pairwise result between every pair of participants: a win for the player who finished ahead, and a draw for players who tied. Each player's KFactor is divided by the number of opponents they faced, so that a single match moves a rating no further than a single head-to-head game would. Every expectation is calculated from the players' ratings before the match, and each player's GamesPlayed increases by one. The returned Outcomes are in the same order as placings.
+func RateFreeForAll(placings []Placing) []Outcome {
+	before := make([]float64, len(placings))
+	for i, pl := range placings {
+		before[i] = pl.Player.Rating
+	}
+	for i, pl := range placings {
+		for j, opponent := range placings {
+			if i != j {
+				pl.Player.addResult(pairwiseScore(pl.Rank, opponent.Rank), before[j], 0, FreeForAll)
+			}
+		}
+	}
+	outcomes := make([]Outcome, len(placings))
+	if len(placings) < 2 {
+		for i := range placings {
+			outcomes[i] = Outcome{Rating: before[i]}
+		}
+		return outcomes
+	}
+	opponents := float64(len(placings) - 1)
+	for i, pl := range placings {
+		rd := 0.0
+		for j, opponent := range placings {
+			if i != j {
+				rd += pl.Player.delta(pairwiseScore(pl.Rank, opponent.Rank), before[i], before[j])
+			}
+		}
+		rd /= opponents
+		outcomes[i] = Outcome{Rating: before[i] + rd, RatingDelta: rd}
+	}
+	for i, pl := range placings {
+		pl.Player.apply(outcomes[i].Rating, 1)
+	}
+	return outcomes
+}
+
+func pairwiseScore(rank, opponentRank int) float64 {
+	if rank < opponentRank {
+		return 1
+	}
+	if rank > opponentRank {
+		return 0
+	}
+	return 0.5
+}
@@ -0,0 +1,48 @@
+package elo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRateFreeForAll(t *testing.T) {
+	players := make([]*Player, 8)
+	placings := make([]Placing, 8)
+	for i := range players {
+		players[i] = NewPlayer(Parameters{InitialRating: 1500})
+		placings[i] = Placing{Player: players[i], Rank: i + 1}
+	}
+	placings[3].Rank = 3
+	outcomes := RateFreeForAll(placings)
+
+	if math.Abs(outcomes[0].RatingDelta-16) > 1e-9 || math.Abs(outcomes[7].RatingDelta+16) > 1e-9 {
+		t.Log(outcomes)
+		t.Fail()
+	}
+	if outcomes[2] != outcomes[3] || players[2].Rating != outcomes[2].Rating {
+		t.Log(outcomes[2], outcomes[3])
+		t.Fail()
+	}
+
+	total := 0.0
+	for i, o := range outcomes {
+		total += o.RatingDelta
+		if players[i].GamesPlayed != 1 || len(players[i].History) != 7 {
+			t.Log(players[i])
+			t.Fail()
+		}
+		if i > 0 && o.Rating > outcomes[i-1].Rating {
+			t.Log(outcomes)
+			t.Fail()
+		}
+	}
+	if math.Abs(total) > 1e-9 {
+		t.Log(total)
+		t.Fail()
+	}
+
+	if outcomes := RateFreeForAll(placings[:1]); len(outcomes) != 1 || outcomes[0].RatingDelta != 0 || outcomes[0].Rating != players[0].Rating {
+		t.Log(outcomes)
+		t.Fail()
+	}
+}
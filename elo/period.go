@@ -0,0 +1,59 @@
+package elo
+
+import (
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/league"
+)
+
+// Match is a single game played within a rating period. Score is the result for Player, using the same values as Win (1), Lose (0), and Draw (0.5). Opponent receives the complementary score. Advantage is the share of Config.Advantage held by Player in this match, such as for home field or the first move: 1 when Player holds it, -1 when Opponent holds it, and 0 at a neutral venue.
+type Match struct {
+	Player, Opponent *Player
+	Score, Advantage float64
+}
+
+// RatePeriod rates a whole rating period at once using the batch form of Elo, in which a player's new rating is their rating from before the period plus the sum of the rating deltas for every game they played in it. Every expectation is calculated from both players' ratings before the period, so the order of the matches and of the players does not matter. Every result is recorded before any KFactorPolicy is consulted, so policies see the whole period in each player's History. Once all results are recorded, every player in players, as well as any player that only appears in matches, is moved to a new rating period with NewPeriod. The returned Outcomes describe the change in each player's Rating over the call.
+func RatePeriod(players []*Player, matches []Match) map[*Player]Outcome {
+	all := league.Participants(players, matches, func(m Match) (*Player, *Player) {
+		return m.Player, m.Opponent
+	})
+	before := make(map[*Player]float64, len(all))
+	for _, p := range all {
+		before[p] = p.Rating
+	}
+	games := make(map[*Player]int, len(all))
+	for _, m := range matches {
+		if m.Player == m.Opponent {
+			continue
+		}
+		m.Player.addResult(m.Score, before[m.Opponent], m.Advantage*m.Player.Config.Advantage, HeadToHead)
+		m.Opponent.addResult(1-m.Score, before[m.Player], -m.Advantage*m.Opponent.Config.Advantage, HeadToHead)
+		games[m.Player]++
+		games[m.Opponent]++
+	}
+	deltas := make(map[*Player]float64, len(all))
+	for _, m := range matches {
+		if m.Player == m.Opponent {
+			continue
+		}
+		player := before[m.Player]
+		opponent := before[m.Opponent]
+		deltas[m.Player] += m.Player.delta(m.Score, player+m.Advantage*m.Player.Config.Advantage, opponent)
+		deltas[m.Opponent] += m.Opponent.delta(1-m.Score, opponent-m.Advantage*m.Opponent.Config.Advantage, player)
+	}
+	outcomes := make(map[*Player]Outcome, len(all))
+	for _, p := range all {
+		p.apply(before[p]+deltas[p], games[p])
+		p.NewPeriod()
+		outcomes[p] = Outcome{Rating: p.Rating, RatingDelta: deltas[p]}
+	}
+	return outcomes
+}
+
+// ratePeriod rates a rating period of a replayed dataset with RatePeriod.
+func ratePeriod(all []*Player, games []rating.Game, players, opponents []*Player) {
+	matches := make([]Match, len(games))
+	for i, g := range games {
+		matches[i] = Match{Player: players[i], Opponent: opponents[i], Score: g.Score, Advantage: g.Advantage}
+	}
+	RatePeriod(all, matches)
+}
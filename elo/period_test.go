@@ -0,0 +1,36 @@
+package elo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatePeriod(t *testing.T) {
+	a := NewPlayer(Parameters{InitialRating: 1500})
+	b := NewPlayer(Parameters{InitialRating: 1500})
+	c := NewPlayer(Parameters{InitialRating: 1600})
+	idle := NewPlayer(Parameters{InitialRating: 1400})
+	outcomes := RatePeriod([]*Player{a, b, c, idle}, []Match{
+		{Player: a, Opponent: b, Score: 1},
+		{Player: c, Opponent: a, Score: 0},
+	})
+
+	if math.Abs(outcomes[a].RatingDelta-36.48) > .01 || math.Abs(outcomes[b].RatingDelta+16) > 1e-9 {
+		t.Log(outcomes[a], outcomes[b])
+		t.Fail()
+	}
+
+	total := 0.0
+	for _, o := range outcomes {
+		total += o.RatingDelta
+	}
+	if math.Abs(total) > 1e-9 || outcomes[idle].RatingDelta != 0 || len(outcomes) != 4 {
+		t.Log(outcomes)
+		t.Fail()
+	}
+
+	if a.Rating != outcomes[a].Rating || a.Parameters.InitialRating != a.Rating || len(a.History) != 0 {
+		t.Log(a)
+		t.Fail()
+	}
+}
@@ -0,0 +1,43 @@
+package elo
+
+import (
+	"math"
+)
+
+// Expected returns the expected score of a player with the given rating against an opponent, which is their probability of winning when draws are counted as half a win. If the Config has a DrawModel, the expected score is taken from its Probabilities. Any advantage held by either side should be added to that side's rating by the caller.
+func (c Config) Expected(rating, opponentRating float64) float64 {
+	c = c.withDefaults()
+	if c.DrawModel != nil {
+		return c.Probabilities(rating, opponentRating).Expected()
+	}
+	return expectation(c.transform(rating), c.transform(opponentRating))
+}
+
+// Gap returns the rating difference at which the higher rated player has the given expected score. It is the inverse of Expected, so a matchmaker can find the widest gap that still gives the weaker side a target chance of winning. Probabilities below 0.5 give a negative gap. If the Config has a DrawModel, its expected score has no closed-form inverse, so the gap is found by bisection to within a thousandth of a rating point.
+func (c Config) Gap(probability float64) float64 {
+	c = c.withDefaults()
+	if c.DrawModel == nil || probability <= 0 || probability >= 1 {
+		return c.D * math.Log10(probability/(1-probability))
+	}
+	lo, hi := -c.D, c.D
+	for c.Expected(lo, 0) > probability {
+		lo *= 2
+	}
+	for c.Expected(hi, 0) < probability {
+		hi *= 2
+	}
+	for hi-lo > 0.001 {
+		mid := (lo + hi) / 2
+		if c.Expected(mid, 0) < probability {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// Expected returns the calling Player's expected score against an opponent with the given rating, using the Player's Config.
+func (p *Player) Expected(opponentRating float64) float64 {
+	return p.Config.Expected(p.Rating, opponentRating)
+}
@@ -0,0 +1,50 @@
+package elo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	c := DefaultConfig()
+	if e := c.Expected(1900, 1500); math.Abs(e-10.0/11) > 0.000001 {
+		t.Log(e)
+		t.Fail()
+	}
+	if e := c.Expected(1500, 1500); e != 0.5 {
+		t.Log(e)
+		t.Fail()
+	}
+	p := NewPlayer(Parameters{InitialRating: 1613})
+	if e := p.Expected(1609); math.Abs(e-0.506) > 0.001 {
+		t.Log(e)
+		t.Fail()
+	}
+}
+
+func TestGap(t *testing.T) {
+	c := DefaultConfig()
+	if g := c.Gap(10.0 / 11); math.Abs(g-400) > 0.000001 {
+		t.Log(g)
+		t.Fail()
+	}
+	for _, probability := range []float64{0.1, 0.5, 0.75, 0.99} {
+		if e := c.Expected(1500+c.Gap(probability), 1500); math.Abs(e-probability) > 0.000001 {
+			t.Log(probability, e)
+			t.Fail()
+		}
+	}
+
+	// With a DrawModel, Gap inverts the expected score that counts predicted draws as half a win.
+	c.DrawModel = Davidson{Draw: 1}
+	if g := c.Gap(10.0 / 11); g <= 400 {
+		t.Log(g)
+		t.Fail()
+	}
+	for _, probability := range []float64{0.1, 0.5, 0.75, 0.99} {
+		if e := c.Expected(1500+c.Gap(probability), 1500); math.Abs(e-probability) > 0.00001 {
+			t.Log(probability, e)
+			t.Fail()
+		}
+	}
+}
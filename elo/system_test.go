@@ -0,0 +1,24 @@
+package elo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestSystem(t *testing.T) {
+	var s rating.System = System{Parameters: Parameters{InitialRating: 1500}}
+	a := s.NewPlayer()
+	b := s.NewPlayer()
+	outcome := a.Win(b.Estimate())
+	b.Lose(rating.Estimate{Rating: 1500})
+	if math.Abs(outcome.Rating-1516) > 1 || math.Abs(b.Estimate().Rating-1484) > 1 {
+		t.Log(a.Estimate(), b.Estimate())
+		t.Fail()
+	}
+	if outcome.Deviation != 0 || outcome.Volatility != 0 {
+		t.Log(outcome)
+		t.Fail()
+	}
+}
@@ -0,0 +1,50 @@
+package elo
+
+import (
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/team"
+)
+
+// Member is a player's participation in a team match. Playtime is the share of the match the player took part in, such as minutes played, and is only used by rating.Playtime aggregation.
+type Member struct {
+	Player   *Player
+	Playtime float64
+}
+
+// RateTeams rates a match between two teams. Score is the result for team, using the same values as Win (1), Lose (0), and Draw (0.5), and opponents receive the complementary score. Each team is combined into a composite rating using the given aggregation and the expectation is calculated from the composites before the match. As Elo has no measure of uncertainty, every member is treated as equally uncertain, so each member's share of their team's change depends only on their weight: with rating.Mean every member moves by the full team change, and with rating.Sum the change is split evenly. Each member's own KFactor is used, and their GamesPlayed increases by one. The returned Outcomes are in the same order as the members.
+func RateTeams(team, opponents []Member, score float64, aggregation rating.Aggregation) ([]Outcome, []Outcome) {
+	a, aw := composite(team, aggregation)
+	b, bw := composite(opponents, aggregation)
+	return rateMembers(team, aw, score, a, b), rateMembers(opponents, bw, 1-score, b, a)
+}
+
+func composite(members []Member, aggregation rating.Aggregation) (float64, []float64) {
+	playtimes := make([]float64, len(members))
+	ratings := make([]float64, len(members))
+	ones := make([]float64, len(members))
+	for i, m := range members {
+		playtimes[i] = m.Playtime
+		ratings[i] = m.Player.Rating
+		ones[i] = 1
+	}
+	weights := aggregation.Weights(playtimes)
+	r, _ := team.Composite(weights, ratings, ones)
+	return r, weights
+}
+
+func rateMembers(members []Member, weights []float64, score, r, opponentRating float64) []Outcome {
+	outcomes := make([]Outcome, len(members))
+	ones := make([]float64, len(members))
+	for i := range ones {
+		ones[i] = 1
+	}
+	_, deviation := team.Composite(weights, ones, ones)
+	for i, m := range members {
+		player := m.Player
+		player.addResult(score, opponentRating, 0, TeamMatch)
+		deltas, _ := team.Distribute(weights, ones, player.delta(score, r, opponentRating), deviation, deviation)
+		player.apply(player.Rating+deltas[i], 1)
+		outcomes[i] = Outcome{Rating: player.Rating, RatingDelta: deltas[i]}
+	}
+	return outcomes
+}
@@ -0,0 +1,34 @@
+package elo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestRateTeams(t *testing.T) {
+	tests := []struct {
+		aggregation rating.Aggregation
+		deltas      []float64
+	}{
+		{rating.Mean, []float64{16, 16}},
+		{rating.Sum, []float64{8, 8}},
+		{rating.Playtime, []float64{19.2, 6.4}},
+	}
+	for _, test := range tests {
+		a := []Member{{NewPlayer(Parameters{InitialRating: 1500}), 3}, {NewPlayer(Parameters{InitialRating: 1500}), 1}}
+		b := []Member{{NewPlayer(Parameters{InitialRating: 1500}), 1}, {NewPlayer(Parameters{InitialRating: 1500}), 1}}
+		ao, bo := RateTeams(a, b, 1, test.aggregation)
+		for i, delta := range test.deltas {
+			if math.Abs(ao[i].RatingDelta-delta) > 1e-9 || a[i].Player.Rating != ao[i].Rating || a[i].Player.GamesPlayed != 1 {
+				t.Log(test.aggregation, ao)
+				t.Fail()
+			}
+		}
+		if bo[0].RatingDelta >= 0 || bo[0].RatingDelta != bo[1].RatingDelta {
+			t.Log(test.aggregation, bo)
+			t.Fail()
+		}
+	}
+}
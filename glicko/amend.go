@@ -0,0 +1,48 @@
+package glicko
+
+import (
+	"errors"
+)
+
+// ErrNoResult is returned by Void and Amend when the calling Player's History has no result at the given index.
+var ErrNoResult = errors.New("glicko: no result at that index of the History")
+
+// Void removes the result at index i of the calling Player's History, for example because a referee has overturned it, and recalculates the player's Rating and Deviation for the current rating period from the remaining results. If no results remain, the player returns to their initial values for the period. The returned Outcome describes the change caused by the removal. If there is no result at index i, ErrNoResult is returned and the player is left unchanged. To void the result for the opponent as well, call Opponent.Void with the index of the same match in their History.
+func (p *Player) Void(i int) (Outcome, error) {
+	if i < 0 || i >= len(p.History) {
+		return Outcome{}, ErrNoResult
+	}
+	history := make([]Result, 0, len(p.History)-1)
+	history = append(history, p.History[:i]...)
+	p.History = append(history, p.History[i+1:]...)
+	return p.recalculate(), nil
+}
+
+// Amend replaces the score of the result at index i of the calling Player's History, using the same values as Win (1), Lose (0), and Draw (0.5), and recalculates the player's Rating and Deviation for the current rating period. The returned Outcome describes the change caused by the amendment. If there is no result at index i, ErrNoResult is returned and the player is left unchanged. To amend the result for the opponent as well, call Opponent.Amend with the complementary score.
+func (p *Player) Amend(i int, score float64) (Outcome, error) {
+	if i < 0 || i >= len(p.History) {
+		return Outcome{}, ErrNoResult
+	}
+	history := make([]Result, len(p.History))
+	copy(history, p.History)
+	history[i].Score = score
+	p.History = history
+	return p.recalculate(), nil
+}
+
+func (p *Player) recalculate() Outcome {
+	before := *p
+	if len(p.History) == 0 {
+		p.Reset()
+	} else {
+		outcome := p.getOutcome()
+		p.Rating = outcome.Rating
+		p.Deviation = outcome.Deviation
+	}
+	return Outcome{
+		Rating:         p.Rating,
+		RatingDelta:    p.Rating - before.Rating,
+		Deviation:      p.Deviation,
+		DeviationDelta: p.Deviation - before.Deviation,
+	}
+}
@@ -0,0 +1,66 @@
+package glicko
+
+import (
+	"testing"
+)
+
+func TestVoid(t *testing.T) {
+	parameters := Parameters{InitialRating: 1500, InitialDeviation: 200}
+	p := NewPlayer(parameters)
+	p.Win(1400, 30)
+	p.Lose(1550, 100)
+	p.Play(1700, 300, 0.5, 1)
+	before := *p
+
+	// Voiding a result gives the same ratings as if it had never been recorded.
+	o, err := p.Void(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := NewPlayer(parameters)
+	q.Win(1400, 30)
+	q.Play(1700, 300, 0.5, 1)
+	if p.Rating != q.Rating || p.Deviation != q.Deviation || len(p.History) != 2 || len(before.History) != 3 {
+		t.Log(p, q)
+		t.Fail()
+	}
+	if o.Rating != p.Rating || o.RatingDelta != p.Rating-before.Rating {
+		t.Log(o)
+		t.Fail()
+	}
+
+	p.Void(0)
+	p.Void(0)
+	if p.Rating != 1500 || p.Deviation != 200 {
+		t.Log(p)
+		t.Fail()
+	}
+
+	// Once History is empty there is nothing left to void or amend.
+	if _, err := p.Void(0); err != ErrNoResult {
+		t.Log(err)
+		t.Fail()
+	}
+	if _, err := p.Amend(-1, 1); err != ErrNoResult {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestAmend(t *testing.T) {
+	parameters := Parameters{InitialRating: 1500, InitialDeviation: 200}
+	p := NewPlayer(parameters)
+	p.Win(1400, 30)
+	p.Lose(1550, 100)
+	o, err := p.Amend(1, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := NewPlayer(parameters)
+	q.Win(1400, 30)
+	q.Draw(1550, 100)
+	if p.Rating != q.Rating || p.Deviation != q.Deviation || o.RatingDelta <= 0 {
+		t.Log(p, q, o)
+		t.Fail()
+	}
+}
@@ -0,0 +1,61 @@
+package glicko
+
+import (
+	"errors"
+	"math"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/league"
+	"github.com/dylrich/rating/internal/optimize"
+)
+
+// ErrNoC is returned by CalibrateC when periods is not positive, or typicalDeviation is negative or not below MaxDeviation, as no C satisfies them.
+var ErrNoC = errors.New("glicko: CalibrateC needs a positive number of periods and a typical deviation between 0 and MaxDeviation")
+
+// CalibrateC returns a copy of the Config with C chosen so that a player with the given typical deviation grows back to MaxDeviation after the given number of rating periods without games. This is the method Glickman suggests for choosing C. CalibrateC returns ErrNoC if periods is not positive, or if typicalDeviation is negative or not below MaxDeviation.
+func (c Config) CalibrateC(typicalDeviation float64, periods int) (Config, error) {
+	c = c.withDefaults()
+	if periods <= 0 || typicalDeviation < 0 || typicalDeviation >= c.MaxDeviation {
+		return c, ErrNoC
+	}
+	c.C = math.Sqrt((math.Pow(c.MaxDeviation, 2) - math.Pow(typicalDeviation, 2)) / float64(periods))
+	return c, nil
+}
+
+// FitC returns a copy of the Config with C chosen to minimise the mean log loss of predicting each game in a historical dataset. The games must be in chronological order and are replayed one rating period at a time using RatePeriod, with every player starting from the given Parameters. Each game is predicted from both players' ratings before its rating period.
+func (c Config) FitC(p Parameters, games []rating.Game) Config {
+	c = c.withDefaults()
+	c.C = optimize.Golden(func(x float64) float64 {
+		trial := c
+		trial.C = x
+		return trial.logLoss(p, games)
+	}, 1, c.MaxDeviation, 0.01)
+	return c
+}
+
+// FitAdvantage returns a copy of the Config with Advantage chosen to minimise the mean log loss of predicting each game in a historical dataset, replayed in the same way as for FitC. Only games with a non-zero Advantage carry information about it.
+func (c Config) FitAdvantage(p Parameters, games []rating.Game) Config {
+	c = c.withDefaults()
+	c.Advantage = optimize.Golden(func(x float64) float64 {
+		trial := c
+		trial.Advantage = x
+		return trial.logLoss(p, games)
+	}, -c.Scale, c.Scale, 0.01)
+	return c
+}
+
+func (c Config) logLoss(p Parameters, games []rating.Game) float64 {
+	if len(games) == 0 {
+		return 0
+	}
+	loss := 0.0
+	league.Replay[*Player]{
+		NewPlayer: func() *Player { return c.NewPlayer(p) },
+		Predict: func(a, b *Player, g rating.Game) {
+			loss += rating.LogLoss(c.Expected(a.Rating+g.Advantage*c.Advantage, a.Deviation, b.Rating, b.Deviation), g.Score)
+		},
+		RatePeriod: ratePeriod,
+		Skip:       skip,
+	}.Run(games)
+	return loss / float64(len(games))
+}
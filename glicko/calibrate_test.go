@@ -0,0 +1,69 @@
+package glicko
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating/internal/simulate"
+)
+
+func TestCalibrateC(t *testing.T) {
+	c, err := DefaultConfig().CalibrateC(50, 30)
+	if err != nil || math.Abs(c.C-63.25) > .01 || c.Scale != DefaultScale {
+		t.Log(c, err)
+		t.Fail()
+	}
+
+	p := c.NewPlayer(Parameters{InitialDeviation: 50, InitialRating: 1500})
+	p.NewPeriods(30)
+	if math.Abs(p.Deviation-350) > 1e-9 {
+		t.Log(p.Deviation)
+		t.Fail()
+	}
+}
+
+func TestCalibrateCInvalid(t *testing.T) {
+	for _, test := range []struct {
+		typicalDeviation float64
+		periods          int
+	}{
+		{400, 30},
+		{350, 30},
+		{50, 0},
+		{50, -3},
+	} {
+		if _, err := DefaultConfig().CalibrateC(test.typicalDeviation, test.periods); err != ErrNoC {
+			t.Log(test, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestFitC(t *testing.T) {
+	games := simulate.League{Players: 30, Periods: 40, GamesPerPeriod: 20, Drift: 40}.Games(1)
+	params := Parameters{InitialDeviation: DefaultInitialDeviation, InitialRating: DefaultInitialRating}
+	fitted := DefaultConfig().FitC(params, games)
+	if fitted.C <= 1 || fitted.C >= 350 {
+		t.Log(fitted)
+		t.Fail()
+	}
+
+	best := fitted.logLoss(params, games)
+	for _, x := range []float64{1, 10, 150, 350} {
+		trial := fitted
+		trial.C = x
+		if loss := trial.logLoss(params, games); loss < best {
+			t.Log(x, loss, fitted.C, best)
+			t.Fail()
+		}
+	}
+}
+func TestFitAdvantage(t *testing.T) {
+	games := simulate.League{Players: 30, Periods: 40, GamesPerPeriod: 40, Drift: 10, Advantage: 60}.Games(1)
+	params := Parameters{InitialDeviation: DefaultInitialDeviation, InitialRating: DefaultInitialRating}
+	fitted := DefaultConfig().FitAdvantage(params, games)
+	if math.Abs(fitted.Advantage-60) > 15 {
+		t.Log(fitted)
+		t.Fail()
+	}
+}
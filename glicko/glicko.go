@@ -11,13 +11,34 @@ const (
 
 	// DefaultInitialRating is the standard value for an initial rating for players that have no result history from the previous rating period.
 	DefaultInitialRating = 1500
+
+	// DefaultC is the standard value for Config.C.
+	DefaultC = 40.0
+
+	// DefaultProvisionalDeviation is the standard value for Config.ProvisionalDeviation.
+	DefaultProvisionalDeviation = 110.0
+
+	// DefaultScale is the standard value for Config.Scale.
+	DefaultScale = 400.0
 )
 
-var (
+// Config contains the system-wide settings for a league. It is passed to each Player on construction, so leagues with different settings can be rated side by side in the same process. Any zero field is replaced with its package default when the Config is used to create a Player.
+type Config struct {
 	// C is a constant that governs the increase in uncertainty between rating periods.
-	C = 40
-	q = math.Ln10 / 400
-)
+	C float64
+
+	// Scale is the logistic scale of the ratings. A difference of Scale points between two players with no uncertainty means the higher rated player is expected to score ten times as often as the lower rated one.
+	Scale float64
+
+	// MaxDeviation caps the growth of a player's deviation between rating periods. It should be the deviation of a completely unrated player, which is the initial deviation given to new players.
+	MaxDeviation float64
+
+	// Advantage is the number of rating points by which the side with home field, the first move, or a similar edge is expected to outperform its rating in this league. It is applied to matches recorded with Player.Play or Match.Advantage, and can be estimated from historical data with Config.FitAdvantage.
+	Advantage float64
+
+	// ProvisionalDeviation is the deviation above which a player's rating is considered provisional by Player.Provisional, because too little is known about the player for their rating to be relied on.
+	ProvisionalDeviation float64
+}
 
 // Player represents an individual participant in the competition. The Player struct contains the Rating and Deviation measures which all compose the Glicko system's estimation of how skilled that player is as well as how reliable that estimation is. These values are all moment-in-time snapshots, and will be updated on any new results for that player. The Parameters attribute contains initial values for that player which can be used to reconstruct the player's current rating from scratch when combined with the History data. Parameters should be altered at the beginning of a new rating period to be the final Rating and Deviation values of the previous period.
 type Player struct {
@@ -25,6 +46,7 @@ type Player struct {
 	Deviation  float64
 	History    []Result
 	Parameters Parameters
+	Config     Config
 }
 
 // Parameters contains initial values for a player. These are set on instantiation of the player, and can be altered later by using the Player.NewPeriod() method.
@@ -34,7 +56,7 @@ type Parameters struct {
 
 // Result contains the important information from a match that has occurred. The information is used to calculate new ratings when new results are added.
 type Result struct {
-	Rating, Deviation, G, E, Score float64
+	Rating, Deviation, G, E, Score, Advantage float64
 }
 
 // Outcome is a snapshot of the current state for a player, including delta values for each Deviation and Rating change. This information can be passed to users to give them an idea of how much the most recent result has impacted their ranking criteria.
@@ -42,20 +64,30 @@ type Outcome struct {
 	Rating, RatingDelta, Deviation, DeviationDelta float64
 }
 
-// NewPlayer is used to instantiate a new Player object based on the input parameters. If any of the parameters are nil, they will be automatically populated with the default values.
+// DefaultConfig returns a Config populated with the package default values.
+func DefaultConfig() Config {
+	return Config{C: DefaultC, Scale: DefaultScale, MaxDeviation: DefaultInitialDeviation, ProvisionalDeviation: DefaultProvisionalDeviation}
+}
+
+// NewPlayer is used to instantiate a new Player object based on the input parameters, using DefaultConfig for the system settings. If any of the parameters are nil, they will be automatically populated with the default values.
 func NewPlayer(p Parameters) *Player {
+	return DefaultConfig().NewPlayer(p)
+}
+
+// NewPlayer is used to instantiate a new Player object that is rated using the calling Config. If any of the parameters are nil, they will be automatically populated with the default values.
+func (c Config) NewPlayer(p Parameters) *Player {
 	if &p.InitialDeviation == nil {
 		p.InitialDeviation = DefaultInitialDeviation
 	}
 	if &p.InitialRating == nil {
 		p.InitialRating = DefaultInitialRating
 	}
-	return &Player{Rating: p.InitialRating, Deviation: p.InitialDeviation, Parameters: p}
+	return &Player{Rating: p.InitialRating, Deviation: p.InitialDeviation, Parameters: p, Config: c.withDefaults()}
 }
 
 // Win is called when a player has won a match against another player, earning a Glicko score of 1. This function will handle adding the result to the history of the player who wins only. To add the loss record to the opponent's history, call Opponent.Lose(Player) as appropriate.
 func (p *Player) Win(rating, deviation float64) Outcome {
-	p.addResult(rating, deviation, 1)
+	p.addResult(rating, deviation, 1, 0)
 	outcome := p.getOutcome()
 	p.Rating = outcome.Rating
 	p.Deviation = outcome.Deviation
@@ -64,7 +96,7 @@ func (p *Player) Win(rating, deviation float64) Outcome {
 
 // Lose is called when a player has won a match against another player, earning a Glicko score of 0. This function will handle adding the result to the history of the player who loses only. To add the win record to the opponent's history, call Opponent.Win(Player) as appropriate.
 func (p *Player) Lose(rating, deviation float64) Outcome {
-	p.addResult(rating, deviation, 0)
+	p.addResult(rating, deviation, 0, 0)
 	outcome := p.getOutcome()
 	p.Rating = outcome.Rating
 	p.Deviation = outcome.Deviation
@@ -73,7 +105,16 @@ func (p *Player) Lose(rating, deviation float64) Outcome {
 
 // Draw is called when a player has tied in a match against another player, earning a Glicko score of 0.5. This function will handle adding the result to the history of the player this method is called on only. To add the draw record to the opponent's history, call Opponent.Draw(Player) as appropriate.
 func (p *Player) Draw(rating, deviation float64) Outcome {
-	p.addResult(rating, deviation, 0.5)
+	p.addResult(rating, deviation, 0.5, 0)
+	outcome := p.getOutcome()
+	p.Rating = outcome.Rating
+	p.Deviation = outcome.Deviation
+	return outcome
+}
+
+// Play is called when a player has completed a match against another player in which one side may hold an advantage such as home field or the first move. The score uses the same values as Win (1), Lose (0), and Draw (0.5). The advantage is the share of Config.Advantage held by the player: 1 when the player holds it, -1 when the opponent holds it, and 0 at a neutral venue. This function will handle adding the result to the history of the player this method is called on only. To add the result to the opponent's history, call Opponent.Play with the complementary score and the advantage negated.
+func (p *Player) Play(rating, deviation, score, advantage float64) Outcome {
+	p.addResult(rating, deviation, score, advantage*p.Config.Advantage)
 	outcome := p.getOutcome()
 	p.Rating = outcome.Rating
 	p.Deviation = outcome.Deviation
@@ -87,16 +128,22 @@ func (p *Player) Reset() {
 	p.Rating = p.Parameters.InitialRating
 }
 
-// NewPeriod takes the calling Player's current Rating and Deviation, and sets them as the new initital values before resetting the player's history to empty.
+// NewPeriod takes the calling Player's current Rating and Deviation, and sets them as the new initital values before resetting the player's history to empty. The Deviation is increased by one period's worth of uncertainty using Config.C, capped at Config.MaxDeviation. NewPeriod should be called on every player at the end of each rating period, including players who played no games in it, so that the deviation of inactive players keeps growing.
 func (p *Player) NewPeriod() {
-	p.Parameters.InitialDeviation = p.Deviation
+	p.NewPeriods(1)
+}
+
+// NewPeriods behaves like NewPeriod, but increases the Deviation as if the given number of rating periods have elapsed since the player's last rating. This is useful for players who are only advanced when they return after an absence. A value of 0 starts a new period without any increase in Deviation.
+func (p *Player) NewPeriods(elapsed int) {
+	p.Parameters.InitialDeviation = p.Config.inflate(p.Deviation, elapsed)
 	p.Parameters.InitialRating = p.Rating
 	p.Reset()
 }
 
 func (p *Player) getOutcome() Outcome {
-	ds := deviationScore(p.Parameters.InitialDeviation, &p.History)
-	rp := ratingPrime(p.Parameters.InitialRating, ds, &p.History)
+	c := p.Config
+	ds := c.deviationScore(p.Parameters.InitialDeviation, &p.History)
+	rp := c.ratingPrime(p.Parameters.InitialRating, ds, &p.History)
 	dp := deviationPrime(ds)
 	return Outcome{
 		Rating:         rp,
@@ -106,39 +153,67 @@ func (p *Player) getOutcome() Outcome {
 	}
 }
 
-func (p *Player) addResult(rating, deviation, score float64) {
+func (p *Player) addResult(rating, deviation, score, advantage float64) {
 	var r Result
 	r.Deviation = deviation
 	r.Rating = rating
 	r.Score = score
-	g := toG(deviation)
+	r.Advantage = advantage
+	g := p.Config.toG(deviation)
 	r.G = g
-	r.E = toE(p.Parameters.InitialRating, rating, g)
+	r.E = p.Config.toE(p.Parameters.InitialRating+advantage, rating, g)
 	p.History = append(p.History, r)
 }
 
-func toG(deviation float64) float64 {
-	return 1 / math.Sqrt(1+(3*math.Pow(q, 2)*math.Pow(deviation, 2)/math.Pow(math.Pi, 2)))
+func (c Config) withDefaults() Config {
+	if c.C == 0 {
+		c.C = DefaultC
+	}
+	if c.Scale == 0 {
+		c.Scale = DefaultScale
+	}
+	if c.MaxDeviation == 0 {
+		c.MaxDeviation = DefaultInitialDeviation
+	}
+	if c.ProvisionalDeviation == 0 {
+		c.ProvisionalDeviation = DefaultProvisionalDeviation
+	}
+	return c
+}
+
+func (c Config) inflate(deviation float64, elapsed int) float64 {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(math.Sqrt(math.Pow(deviation, 2)+math.Pow(c.C, 2)*float64(elapsed)), c.MaxDeviation)
+}
+
+func (c Config) q() float64 {
+	return math.Ln10 / c.Scale
+}
+
+func (c Config) toG(deviation float64) float64 {
+	return 1 / math.Sqrt(1+(3*math.Pow(c.q(), 2)*math.Pow(deviation, 2)/math.Pow(math.Pi, 2)))
 }
 
-func toE(playerRating, opponentRating, opponentG float64) float64 {
-	return 1 / (1 + math.Pow(10, -opponentG*(playerRating-opponentRating)/400))
+func (c Config) toE(playerRating, opponentRating, opponentG float64) float64 {
+	return 1 / (1 + math.Pow(10, -opponentG*(playerRating-opponentRating)/c.Scale))
 }
 
-func dsquared(history *[]Result) float64 {
-	return math.Pow(math.Pow(q, 2)*totalImpact(history), -1)
+func (c Config) dsquared(history *[]Result) float64 {
+	return math.Pow(math.Pow(c.q(), 2)*totalImpact(history), -1)
 }
 
-func ratingPrime(rating, deviationScore float64, history *[]Result) float64 {
-	return rating + (q/deviationScore)*totalResultScore(history)
+func (c Config) ratingPrime(rating, deviationScore float64, history *[]Result) float64 {
+	return rating + (c.q()/deviationScore)*totalResultScore(history)
 }
 
 func deviationPrime(deviationScore float64) float64 {
 	return math.Sqrt(math.Pow(deviationScore, -1))
 }
 
-func deviationScore(deviation float64, history *[]Result) float64 {
-	return (1 / math.Pow(deviation, 2)) + (1 / dsquared(history))
+func (c Config) deviationScore(deviation float64, history *[]Result) float64 {
+	return (1 / math.Pow(deviation, 2)) + (1 / c.dsquared(history))
 }
 
 func impact(g, e float64) float64 {
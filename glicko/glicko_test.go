@@ -22,22 +22,23 @@ var (
 		InitialDeviation: 300,
 		InitialRating:    1700,
 	})
+	c = DefaultConfig()
 )
 
 func TestToG(t *testing.T) {
-	g := toG(30.0)
+	g := c.toG(30.0)
 	if math.Abs(g-.9955) > .0001 {
 		t.Log(g)
 		t.Fail()
 	}
 
-	g = toG(100.0)
+	g = c.toG(100.0)
 	if math.Abs(g-.9531) > .0001 {
 		t.Log(g)
 		t.Fail()
 	}
 
-	g = toG(300.0)
+	g = c.toG(300.0)
 	if math.Abs(g-.7242) > .0001 {
 		t.Log(g)
 		t.Fail()
@@ -45,19 +46,19 @@ func TestToG(t *testing.T) {
 }
 
 func TestToE(t *testing.T) {
-	e := toE(1500, 1400, .9955)
+	e := c.toE(1500, 1400, .9955)
 	if math.Abs(e-.639) > .001 {
 		t.Log(e)
 		t.Fail()
 	}
 
-	e = toE(1500, 1550, .9531)
+	e = c.toE(1500, 1550, .9531)
 	if math.Abs(e-.432) > .001 {
 		t.Log(e)
 		t.Fail()
 	}
 
-	e = toE(1500, 1700, .7242)
+	e = c.toE(1500, 1700, .7242)
 	if math.Abs(e-.303) > .001 {
 		t.Log(e)
 		t.Fail()
@@ -66,10 +67,10 @@ func TestToE(t *testing.T) {
 
 func TestDSquared(t *testing.T) {
 	p1.Reset()
-	p1.addResult(p2.Rating, p2.Deviation, 1)
-	p1.addResult(p3.Rating, p3.Deviation, 0)
-	p1.addResult(p4.Rating, p4.Deviation, 0)
-	ds := dsquared(&p1.History)
+	p1.addResult(p2.Rating, p2.Deviation, 1, 0)
+	p1.addResult(p3.Rating, p3.Deviation, 0, 0)
+	p1.addResult(p4.Rating, p4.Deviation, 0, 0)
+	ds := c.dsquared(&p1.History)
 	if math.Abs(ds-53685.74) > 0.01 {
 		t.Log(ds)
 		t.Fail()
@@ -97,3 +98,42 @@ func BenchmarkGlicko(b *testing.B) {
 		p1.Win(p2.Rating, p2.Deviation)
 	}
 }
+
+func TestNewPeriod(t *testing.T) {
+	p := NewPlayer(Parameters{InitialDeviation: 50, InitialRating: 1500})
+	p.NewPeriod()
+	if math.Abs(p.Deviation-64.03) > 0.01 || p.Parameters.InitialDeviation != p.Deviation {
+		t.Log(p.Deviation)
+		t.Fail()
+	}
+
+	p.NewPeriods(3)
+	if math.Abs(p.Deviation-math.Sqrt(50*50+4*40*40)) > 0.01 {
+		t.Log(p.Deviation)
+		t.Fail()
+	}
+
+	p.NewPeriods(100)
+	if p.Deviation != DefaultInitialDeviation || p.Rating != 1500 {
+		t.Log(p.Deviation)
+		t.Fail()
+	}
+
+	p.NewPeriods(0)
+	if p.Deviation != DefaultInitialDeviation {
+		t.Log(p.Deviation)
+		t.Fail()
+	}
+}
+
+func TestPlay(t *testing.T) {
+	league := Config{Advantage: 50}
+	home := league.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1450})
+	neutral := league.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})
+	ho := home.Play(1400, 30, 1, 1)
+	no := neutral.Win(1400, 30)
+	if math.Abs(ho.RatingDelta-no.RatingDelta) > 1e-9 || home.History[0].Advantage != 50 {
+		t.Log(ho, no)
+		t.Fail()
+	}
+}
@@ -0,0 +1,65 @@
+package glicko
+
+import (
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/league"
+)
+
+// Match is a single game played within a rating period. Score is the result for Player, using the same values as Win (1), Lose (0), and Draw (0.5). Opponent receives the complementary score. Advantage is the share of Config.Advantage held by Player in this match, such as for home field or the first move: 1 when Player holds it, -1 when Opponent holds it, and 0 at a neutral venue.
+type Match struct {
+	Player, Opponent *Player
+	Score, Advantage float64
+}
+
+// RatePeriod rates a whole rating period at once, as Glicko is designed to be used. Every result is recorded against the opponent's Rating and Deviation from before the period, so the order of the matches and of the players does not matter. Once all results are recorded, every player in players, as well as any player that only appears in matches, is moved to a new rating period with NewPeriod, including players who played no games. The returned Outcomes describe the change in each player's state over the call.
+func RatePeriod(players []*Player, matches []Match) map[*Player]Outcome {
+	all := league.Participants(players, matches, func(m Match) (*Player, *Player) {
+		return m.Player, m.Opponent
+	})
+	before := make(map[*Player]Player, len(all))
+	for _, p := range all {
+		before[p] = *p
+	}
+	for _, m := range matches {
+		if m.Player == m.Opponent {
+			continue
+		}
+		player := before[m.Player]
+		opponent := before[m.Opponent]
+		m.Player.addResult(opponent.Rating, opponent.Deviation, m.Score, m.Advantage*m.Player.Config.Advantage)
+		m.Opponent.addResult(player.Rating, player.Deviation, 1-m.Score, -m.Advantage*m.Opponent.Config.Advantage)
+	}
+	outcomes := make(map[*Player]Outcome, len(all))
+	for _, p := range all {
+		if len(p.History) > 0 {
+			outcome := p.getOutcome()
+			p.Rating = outcome.Rating
+			p.Deviation = outcome.Deviation
+		}
+		p.NewPeriod()
+		b := before[p]
+		outcomes[p] = Outcome{
+			Rating:         p.Rating,
+			RatingDelta:    p.Rating - b.Rating,
+			Deviation:      p.Deviation,
+			DeviationDelta: p.Deviation - b.Deviation,
+		}
+	}
+	return outcomes
+}
+
+// ratePeriod rates a rating period of a replayed dataset with RatePeriod.
+func ratePeriod(all []*Player, games []rating.Game, players, opponents []*Player) {
+	matches := make([]Match, len(games))
+	for i, g := range games {
+		matches[i] = Match{Player: players[i], Opponent: opponents[i], Score: g.Score, Advantage: g.Advantage}
+	}
+	RatePeriod(all, matches)
+}
+
+// skip moves every player in a replayed dataset on by the given number of rating periods without games.
+func skip(all []*Player, periods int) {
+	for _, p := range all {
+		p.NewPeriods(periods)
+	}
+}
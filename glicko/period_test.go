@@ -0,0 +1,35 @@
+package glicko
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatePeriod(t *testing.T) {
+	a := NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})
+	b := NewPlayer(Parameters{InitialDeviation: 30, InitialRating: 1400})
+	c := NewPlayer(Parameters{InitialDeviation: 100, InitialRating: 1550})
+	d := NewPlayer(Parameters{InitialDeviation: 300, InitialRating: 1700})
+	idle := NewPlayer(Parameters{InitialDeviation: 50, InitialRating: 1500})
+	outcomes := RatePeriod([]*Player{a, idle}, []Match{
+		{Player: a, Opponent: b, Score: 1},
+		{Player: c, Opponent: a, Score: 1},
+		{Player: a, Opponent: d, Score: 0},
+	})
+
+	if math.Abs(outcomes[a].Rating-1464.1) > 0.1 || math.Abs(outcomes[a].Deviation-math.Sqrt(151.4*151.4+40*40)) > 0.1 {
+		t.Log(outcomes[a])
+		t.Fail()
+	}
+
+	expected := NewPlayer(Parameters{InitialDeviation: 30, InitialRating: 1400}).Lose(1500, 200)
+	if math.Abs(outcomes[b].Rating-expected.Rating) > 1e-9 || len(outcomes) != 5 {
+		t.Log(outcomes[b], expected)
+		t.Fail()
+	}
+
+	if outcomes[idle].Rating != 1500 || math.Abs(outcomes[idle].Deviation-64.03) > 0.01 {
+		t.Log(outcomes[idle])
+		t.Fail()
+	}
+}
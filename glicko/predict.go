@@ -0,0 +1,22 @@
+package glicko
+
+import (
+	"math"
+)
+
+// Expected returns the expected score of a player against an opponent, which is their probability of winning when draws are counted as half a win. Both players' uncertainty is taken into account by using the combined deviation sqrt(RD₁² + RD₂²), so the prediction is pulled towards 0.5 when either rating is unreliable. Any advantage held by either side should be added to that side's rating by the caller.
+func (c Config) Expected(rating, deviation, opponentRating, opponentDeviation float64) float64 {
+	c = c.withDefaults()
+	return c.toE(rating, opponentRating, c.toG(math.Hypot(deviation, opponentDeviation)))
+}
+
+// Gap returns the rating difference at which the higher rated player has the given expected score, for players with the given deviations. It is the inverse of Expected, so a matchmaker can find the widest gap that still gives the weaker side a target chance of winning. The more uncertain the ratings, the wider the gap needed for the same probability. Probabilities below 0.5 give a negative gap.
+func (c Config) Gap(probability, deviation, opponentDeviation float64) float64 {
+	c = c.withDefaults()
+	return c.Scale * math.Log10(probability/(1-probability)) / c.toG(math.Hypot(deviation, opponentDeviation))
+}
+
+// Expected returns the calling Player's expected score against an opponent with the given rating and deviation, using the Player's Config.
+func (p *Player) Expected(rating, deviation float64) float64 {
+	return p.Config.Expected(p.Rating, p.Deviation, rating, deviation)
+}
@@ -0,0 +1,49 @@
+package glicko
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	// With no uncertainty, Glicko's expected score is the Elo expectation.
+	if e := c.Expected(1900, 0, 1500, 0); math.Abs(e-10.0/11) > 0.000001 {
+		t.Log(e)
+		t.Fail()
+	}
+
+	// The combined deviation of 30 and 40 is 50.
+	if e, g := c.Expected(1700, 30, 1500, 40), c.toG(50); math.Abs(e-1/(1+math.Pow(10, -g*200/400))) > 0.000001 {
+		t.Log(e)
+		t.Fail()
+	}
+
+	// Uncertainty pulls the prediction towards 0.5.
+	if certain, uncertain := c.Expected(1700, 50, 1500, 50), c.Expected(1700, 300, 1500, 300); uncertain >= certain || uncertain <= 0.5 {
+		t.Log(certain, uncertain)
+		t.Fail()
+	}
+
+	p := NewPlayer(Parameters{InitialRating: 1700, InitialDeviation: 30})
+	if e := p.Expected(1500, 40); e != c.Expected(1700, 30, 1500, 40) {
+		t.Log(e)
+		t.Fail()
+	}
+}
+
+func TestGap(t *testing.T) {
+	if g := c.Gap(10.0/11, 0, 0); math.Abs(g-400) > 0.000001 {
+		t.Log(g)
+		t.Fail()
+	}
+	for _, probability := range []float64{0.1, 0.5, 0.75, 0.99} {
+		gap := c.Gap(probability, 80, 200)
+		if e := c.Expected(1500+gap, 80, 1500, 200); math.Abs(e-probability) > 0.000001 {
+			t.Log(probability, e)
+			t.Fail()
+		}
+	}
+	if c.Gap(0.75, 300, 300) <= c.Gap(0.75, 50, 50) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,58 @@
+package glicko
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInterval(t *testing.T) {
+	p := DefaultConfig().NewPlayer(Parameters{InitialRating: 1500, InitialDeviation: 100})
+	low, high := p.Interval(0.95)
+	if math.Abs(low-(1500-195.996)) > 0.001 || math.Abs(high-(1500+195.996)) > 0.001 {
+		t.Log(low, high)
+		t.Fail()
+	}
+	low, high = p.Interval(0.6827)
+	if math.Abs(low-1400) > 0.01 || math.Abs(high-1600) > 0.01 {
+		t.Log(low, high)
+		t.Fail()
+	}
+	if c := p.Conservative(2); c != 1300 {
+		t.Log(c)
+		t.Fail()
+	}
+}
+
+func TestProvisional(t *testing.T) {
+	p := DefaultConfig().NewPlayer(Parameters{InitialRating: 1500, InitialDeviation: 350})
+	if !p.Provisional() {
+		t.Fail()
+	}
+	p.Deviation = 100
+	if p.Provisional() {
+		t.Fail()
+	}
+	c := DefaultConfig()
+	c.ProvisionalDeviation = 80
+	if !c.NewPlayer(Parameters{InitialRating: 1500, InitialDeviation: 100}).Provisional() {
+		t.Fail()
+	}
+}
+
+func TestProbabilityStronger(t *testing.T) {
+	p := DefaultConfig().NewPlayer(Parameters{InitialRating: 1600, InitialDeviation: 30})
+
+	// The combined deviation of 30 and 40 is 50, so a 100 point lead is two standard deviations.
+	if s := p.ProbabilityStronger(1500, 40); math.Abs(s-0.97725) > 0.00001 {
+		t.Log(s)
+		t.Fail()
+	}
+	if s := p.ProbabilityStronger(1600, 40); s != 0.5 {
+		t.Log(s)
+		t.Fail()
+	}
+	p.Deviation = 0
+	if p.ProbabilityStronger(1599, 0) != 1 || p.ProbabilityStronger(1601, 0) != 0 {
+		t.Fail()
+	}
+}
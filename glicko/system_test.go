@@ -0,0 +1,24 @@
+package glicko
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestSystem(t *testing.T) {
+	var s rating.System = System{Parameters: Parameters{InitialDeviation: 200, InitialRating: 1500}}
+	a := s.NewPlayer()
+	a.Win(rating.Estimate{Rating: p2.Rating, Deviation: p2.Deviation})
+	a.Lose(rating.Estimate{Rating: p3.Rating, Deviation: p3.Deviation})
+	outcome := a.Lose(rating.Estimate{Rating: p4.Rating, Deviation: p4.Deviation})
+	if math.Abs(outcome.Rating-1464.1) > 0.1 || math.Abs(outcome.Deviation-151.4) > 0.1 {
+		t.Log(outcome)
+		t.Fail()
+	}
+	if a.Estimate() != outcome.Estimate() {
+		t.Log(a.Estimate(), outcome)
+		t.Fail()
+	}
+}
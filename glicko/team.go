@@ -0,0 +1,53 @@
+package glicko
+
+import (
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/team"
+)
+
+// Member is a player's participation in a team match. Playtime is the share of the match the player took part in, such as minutes played, and is only used by rating.Playtime aggregation.
+type Member struct {
+	Player   *Player
+	Playtime float64
+}
+
+// RateTeams rates a match between two teams. Score is the result for team, using the same values as Win (1), Lose (0), and Draw (0.5), and opponents receive the complementary score. Each team is combined into a composite rating and deviation using the given aggregation, the composites are rated against each other from their state before the match, and each member's rating moves in proportion to their weight and the uncertainty in their own rating. The result is added to each member's History and rated together with the rest of their current rating period, so it can be voided or amended with Void and Amend in the same way as an individual result. The returned Outcomes are in the same order as the members.
+func RateTeams(team, opponents []Member, score float64, aggregation rating.Aggregation) ([]Outcome, []Outcome) {
+	a := newSide(team, aggregation)
+	b := newSide(opponents, aggregation)
+	return rateSide(team, a, b, score), rateSide(opponents, b, a, 1-score)
+}
+
+func newSide(members []Member, aggregation rating.Aggregation) team.Side {
+	m := make([]team.Member, len(members))
+	for i, member := range members {
+		p := member.Player
+		m[i] = team.Member{Playtime: member.Playtime, Rating: p.Rating, InitialRating: p.Parameters.InitialRating, Deviation: p.Deviation}
+	}
+	return team.NewSide(m, aggregation)
+}
+
+// rateSide adds the result of a team match to the History of each member of a side and rates their current rating period.
+func rateSide(members []Member, side, opponent team.Side, score float64) []Outcome {
+	outcomes := make([]Outcome, len(members))
+	for i, m := range members {
+		p := m.Player
+		p.History = append(p.History, p.Config.teamResult(side.Result(i, opponent), score))
+		o := p.getOutcome()
+		p.Rating = o.Rating
+		p.Deviation = o.Deviation
+		outcomes[i] = o
+	}
+	return outcomes
+}
+
+func (c Config) teamResult(r team.Result, score float64) Result {
+	g := c.toG(r.Opposition)
+	return Result{
+		Rating:    r.Rating,
+		Deviation: r.Deviation,
+		G:         r.Weight * g,
+		E:         c.toE(r.Team, r.Rating, g),
+		Score:     score,
+	}
+}
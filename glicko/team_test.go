@@ -0,0 +1,53 @@
+package glicko
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestRateTeams(t *testing.T) {
+	single := NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})
+	expected := single.Win(1500, 200)
+	a := []Member{{Player: NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})}}
+	b := []Member{{Player: NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})}}
+	ao, _ := RateTeams(a, b, 1, rating.Mean)
+	if math.Abs(ao[0].Rating-expected.Rating) > 1e-9 || math.Abs(ao[0].Deviation-expected.Deviation) > 1e-9 {
+		t.Log(ao, expected)
+		t.Fail()
+	}
+
+	certain := NewPlayer(Parameters{InitialDeviation: 50, InitialRating: 1600})
+	uncertain := NewPlayer(Parameters{InitialDeviation: 300, InitialRating: 1400})
+	a = []Member{{Player: certain}, {Player: uncertain}}
+	b = []Member{{Player: NewPlayer(Parameters{InitialDeviation: 100, InitialRating: 1500})}, {Player: NewPlayer(Parameters{InitialDeviation: 100, InitialRating: 1500})}}
+	ao, bo := RateTeams(a, b, 0, rating.Mean)
+	if ao[0].RatingDelta >= 0 || ao[1].RatingDelta >= ao[0].RatingDelta || ao[1].DeviationDelta >= ao[0].DeviationDelta {
+		t.Log(ao)
+		t.Fail()
+	}
+	if bo[0].RatingDelta <= 0 || bo[0] != bo[1] {
+		t.Log(ao, bo)
+		t.Fail()
+	}
+	if len(certain.History) != 1 || certain.Parameters.InitialRating != 1600 || certain.Parameters.InitialDeviation != 50 {
+		t.Log(certain)
+		t.Fail()
+	}
+
+	// A team result is rated together with the rest of the period, and can be voided like any other result.
+	p := NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})
+	p.Win(1400, 50)
+	want := *p
+	RateTeams([]Member{{Player: p}}, []Member{{Player: NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})}}, 0, rating.Mean)
+	if len(p.History) != 2 || p.Parameters != want.Parameters || p.Rating >= want.Rating {
+		t.Log(p)
+		t.Fail()
+	}
+	p.Void(1)
+	if math.Abs(p.Rating-want.Rating) > 1e-9 || math.Abs(p.Deviation-want.Deviation) > 1e-9 {
+		t.Log(p, want)
+		t.Fail()
+	}
+}
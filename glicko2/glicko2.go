@@ -14,18 +14,44 @@ const (
 
 	// DefaultInitialVolatility is the standard value for an initial volatility for players that have no result history from the previous rating period.
 	DefaultInitialVolatility = 0.06
-)
 
-var (
-	q = math.Ln10 / 400
+	// DefaultSystemConstant is the standard value for Config.SystemConstant.
+	DefaultSystemConstant = 0.6
+
+	// DefaultConvergenceTolerance is the standard value for Config.ConvergenceTolerance.
+	DefaultConvergenceTolerance = 0.000001
+
+	// DefaultCenter is the standard value for Config.Center.
+	DefaultCenter = 1500.0
 
-	// SystemConstant (τ) constrains the change in volatility over time. It  needs to be set  prior  to  application  of  the  system. Reasonable  choices  are  between  0.3  and  1.2 ,though the system should be tested to decide which value results in greatest predictive accuracy. Smaller values of τ prevent the volatility measures from changing by large amounts, which in turn prevent enormous changes in ratings based on very improbable results. If  the  application  of  Glicko2  is  expected  to  involve  extremely  improbable collections of game outcomes, then τ should be set to a small value, even as small as τ = 0.
-	SystemConstant = 0.6
+	// DefaultProvisionalDeviation is the standard value for Config.ProvisionalDeviation.
+	DefaultProvisionalDeviation = 110.0
 
-	// ConverganceTolerance (ε) is the value that the illinois algorithm uses to detect whether A and B have converged to each other.
-	ConverganceTolerance = 0.000001
+	// DefaultScale is the standard value for Config.Scale.
+	DefaultScale = 173.7178
 )
 
+// Config contains the system-wide settings for a league. It is passed to each Player on construction, so leagues with different settings can be rated side by side in the same process. Any zero field is replaced with its package default when the Config is used to create a Player.
+type Config struct {
+	// SystemConstant (τ) constrains the change in volatility over time. It  needs to be set  prior  to  application  of  the  system. Reasonable  choices  are  between  0.3  and  1.2 ,though the system should be tested to decide which value results in greatest predictive accuracy, for example with Config.Tune. Smaller values of τ prevent the volatility measures from changing by large amounts, which in turn prevent enormous changes in ratings based on very improbable results. If  the  application  of  Glicko2  is  expected  to  involve  extremely  improbable collections of game outcomes, then τ should be set to a small value. Like the other fields, a SystemConstant of 0 is replaced with DefaultSystemConstant, so 0 means 0.6 rather than τ = 0, which would stop volatilities from ever changing and is not supported by the volatility update. To keep volatilities nearly fixed, use a small positive value such as 0.01.
+	SystemConstant float64
+
+	// ConvergenceTolerance (ε) is the value that the illinois algorithm uses to detect whether A and B have converged to each other.
+	ConvergenceTolerance float64
+
+	// Center is the rating that maps to μ = 0 on the Glicko2 scale.
+	Center float64
+
+	// Scale is the factor used to convert ratings and deviations to and from the Glicko2 scale. The default of 173.7178 (400 / ln 10) keeps ratings comparable with the original Glicko system.
+	Scale float64
+
+	// Advantage is the number of rating points by which the side with home field, the first move, or a similar edge is expected to outperform its rating in this league. It is applied to matches recorded with Player.Play or Match.Advantage, and can be estimated from historical data with Config.FitAdvantage.
+	Advantage float64
+
+	// ProvisionalDeviation is the deviation above which a player's rating is considered provisional by Player.Provisional, because too little is known about the player for their rating to be relied on.
+	ProvisionalDeviation float64
+}
+
 // Player represents an individual participant in the competition. The Player struct contains the Rating, Deviation, and Volatility measures which all compose the Glicko2 system's estimation of how skilled that player is as well as how reliable that estimation is. These values are all moment-in-time snapshots, and will be updated on any new results for that player. The Parameters attribute contains initial values for that player which can be used to reconstruct the player's current rating from scratch when combined with the History data. Parameters should be altered at the beginning of a new rating period to be the final Rating, Deviation, and Volatility values of the previous period.
 type Player struct {
 	Rating     float64
@@ -33,6 +59,7 @@ type Player struct {
 	Volatility float64
 	History    []Result
 	Parameters Parameters
+	Config     Config
 }
 
 // Parameters contains initial values for a player. These are set on instantiation of the player, and can be altered later by using the Player.NewPeriod() method.
@@ -42,7 +69,7 @@ type Parameters struct {
 
 // Result contains the important information from a match that has occurred. The information is used to calculate new ratings when new results are added.
 type Result struct {
-	Rating, Deviation, G, E, Score float64
+	Rating, Deviation, G, E, Score, Advantage float64
 }
 
 // Outcome is a snapshot of the current state for a player, including delta values for each Deviation, Rating, and Volatility change. This information can be passed to users to give them an idea of how much the most recent result has impacted their ranking criteria.
@@ -50,8 +77,24 @@ type Outcome struct {
 	Rating, RatingDelta, Deviation, DeviationDelta, Volatility, VolatilityDelta float64
 }
 
-// NewPlayer is used to instantiate a new Player object based on the input parameters. If any of the parameters are nil, they will be automatically populated with the default values.
+// DefaultConfig returns a Config populated with the package default values.
+func DefaultConfig() Config {
+	return Config{
+		SystemConstant:       DefaultSystemConstant,
+		ConvergenceTolerance: DefaultConvergenceTolerance,
+		Center:               DefaultCenter,
+		Scale:                DefaultScale,
+		ProvisionalDeviation: DefaultProvisionalDeviation,
+	}
+}
+
+// NewPlayer is used to instantiate a new Player object based on the input parameters, using DefaultConfig for the system settings. If any of the parameters are nil, they will be automatically populated with the default values.
 func NewPlayer(p Parameters) *Player {
+	return DefaultConfig().NewPlayer(p)
+}
+
+// NewPlayer is used to instantiate a new Player object that is rated using the calling Config. If any of the parameters are nil, they will be automatically populated with the default values.
+func (c Config) NewPlayer(p Parameters) *Player {
 	if &p.InitialDeviation == nil {
 		p.InitialDeviation = DefaultInitialDeviation
 	}
@@ -62,12 +105,12 @@ func NewPlayer(p Parameters) *Player {
 		p.InitialVolatility = DefaultInitialVolatility
 	}
 
-	return &Player{Rating: p.InitialRating, Deviation: p.InitialDeviation, Volatility: p.InitialVolatility, Parameters: p}
+	return &Player{Rating: p.InitialRating, Deviation: p.InitialDeviation, Volatility: p.InitialVolatility, Parameters: p, Config: c.withDefaults()}
 }
 
 // Win is called when a player has won a match against another player, earning a Glicko2 score of 1. This function will handle adding the result to the history of the player who wins only. To add the loss record to the opponent's history, call Opponent.Lose(Player) as appropriate.
 func (p *Player) Win(rating, deviation float64) Outcome {
-	p.addResult(rating, deviation, 1)
+	p.addResult(rating, deviation, 1, 0)
 	outcome := p.getOutcome()
 	p.Deviation = outcome.Deviation
 	p.Rating = outcome.Rating
@@ -77,7 +120,7 @@ func (p *Player) Win(rating, deviation float64) Outcome {
 
 // Lose is called when a player has won a match against another player, earning a Glicko2 score of 0. This function will handle adding the result to the history of the player who loses only. To add the win record to the opponent's history, call Opponent.Win(Player) as appropriate.
 func (p *Player) Lose(rating, deviation float64) Outcome {
-	p.addResult(rating, deviation, 0)
+	p.addResult(rating, deviation, 0, 0)
 	outcome := p.getOutcome()
 	p.Deviation = outcome.Deviation
 	p.Rating = outcome.Rating
@@ -87,7 +130,17 @@ func (p *Player) Lose(rating, deviation float64) Outcome {
 
 // Draw is called when a player has tied in a match against another player, earning a Glicko2 score of 0.5. This function will handle adding the result to the history of the player this method is called on only. To add the draw record to the opponent's history, call Opponent.Draw(Player) as appropriate.
 func (p *Player) Draw(rating, deviation float64) Outcome {
-	p.addResult(rating, deviation, 0.5)
+	p.addResult(rating, deviation, 0.5, 0)
+	outcome := p.getOutcome()
+	p.Deviation = outcome.Deviation
+	p.Rating = outcome.Rating
+	p.Volatility = outcome.Volatility
+	return outcome
+}
+
+// Play is called when a player has completed a match against another player in which one side may hold an advantage such as home field or the first move. The score uses the same values as Win (1), Lose (0), and Draw (0.5). The advantage is the share of Config.Advantage held by the player: 1 when the player holds it, -1 when the opponent holds it, and 0 at a neutral venue. This function will handle adding the result to the history of the player this method is called on only. To add the result to the opponent's history, call Opponent.Play with the complementary score and the advantage negated.
+func (p *Player) Play(rating, deviation, score, advantage float64) Outcome {
+	p.addResult(rating, deviation, score, advantage*p.Config.Advantage)
 	outcome := p.getOutcome()
 	p.Deviation = outcome.Deviation
 	p.Rating = outcome.Rating
@@ -103,36 +156,60 @@ func (p *Player) Reset() {
 	p.Volatility = p.Parameters.InitialVolatility
 }
 
-// NewPeriod takes the calling Player's current Rating, Volatility, and Deviation, and sets them as the new initital values before resetting the player's history to empty.
+// NewPeriod takes the calling Player's current Rating, Volatility, and Deviation, and sets them as the new initital values before resetting the player's history to empty. If the player has no results in the period being closed, their Rating and Volatility are kept and their Deviation grows to sqrt(φ² + σ²) as described by Glickman. NewPeriod should therefore be called on every player at the end of each rating period, including players who did not compete.
 func (p *Player) NewPeriod() {
+	p.NewPeriods(1)
+}
+
+// NewPeriods behaves like NewPeriod, but closes the given number of rating periods at once. The current period is closed with whatever results it holds, and every additional period is treated as one in which the player did not compete. This is useful for players who are only advanced when they return after an absence. A value of 0 starts a new period without closing any, so an idle player's Deviation does not grow.
+func (p *Player) NewPeriods(elapsed int) {
+	if len(p.History) > 0 {
+		elapsed--
+	}
+	for ; elapsed > 0; elapsed-- {
+		p.Deviation = p.Config.fromPhi(rd(p.Config.toPhi(p.Deviation), p.Volatility))
+	}
 	p.Parameters.InitialDeviation = p.Deviation
 	p.Parameters.InitialRating = p.Rating
 	p.Parameters.InitialVolatility = p.Volatility
 	p.Reset()
 }
 
-func (p *Player) addResult(rating, deviation, score float64) {
+func (p *Player) addResult(rating, deviation, score, advantage float64) {
 	var r Result
 	r.Deviation = deviation
 	r.Rating = rating
 	r.Score = score
-	g := toG(deviation)
+	r.Advantage = advantage
+	g := p.Config.toG(deviation)
 	r.G = g
-	r.E = toE(p.Parameters.InitialRating, rating, g)
+	r.E = p.Config.toE(p.Parameters.InitialRating+advantage, rating, g)
 	p.History = append(p.History, r)
 }
 
 func (p *Player) getOutcome() Outcome {
-	mu := toMu(p.Parameters.InitialRating)
-	phi := toPhi(p.Parameters.InitialDeviation)
+	c := p.Config
+	mu := c.toMu(p.Parameters.InitialRating)
+	phi := c.toPhi(p.Parameters.InitialDeviation)
+	if len(p.History) == 0 {
+		deviation := c.fromPhi(rd(phi, p.Parameters.InitialVolatility))
+		return Outcome{
+			Rating:          p.Parameters.InitialRating,
+			RatingDelta:     p.Parameters.InitialRating - p.Rating,
+			Deviation:       deviation,
+			DeviationDelta:  deviation - p.Deviation,
+			Volatility:      p.Parameters.InitialVolatility,
+			VolatilityDelta: p.Parameters.InitialVolatility - p.Volatility,
+		}
+	}
 	ti := totalImpact(&p.History)
 	ts := totalResultScore(&p.History)
 	variance := variance(ti)
 	delta := delta(variance, ts)
-	volatility := volatility(p.Parameters.InitialVolatility, variance, phi, delta)
+	volatility := c.volatility(p.Parameters.InitialVolatility, variance, phi, delta)
 	pp := phiPrime(rd(phi, volatility), variance)
-	deviation := fromPhi(pp)
-	rating := fromMu(muPrime(mu, pp, ts))
+	deviation := c.fromPhi(pp)
+	rating := c.fromMu(muPrime(mu, pp, ts))
 	return Outcome{
 		Rating:          rating,
 		RatingDelta:     rating - p.Rating,
@@ -143,15 +220,34 @@ func (p *Player) getOutcome() Outcome {
 	}
 }
 
-func volatility(sigma, variance, phi, delta float64) float64 {
+func (c Config) withDefaults() Config {
+	if c.SystemConstant == 0 {
+		c.SystemConstant = DefaultSystemConstant
+	}
+	if c.ConvergenceTolerance == 0 {
+		c.ConvergenceTolerance = DefaultConvergenceTolerance
+	}
+	if c.Center == 0 {
+		c.Center = DefaultCenter
+	}
+	if c.Scale == 0 {
+		c.Scale = DefaultScale
+	}
+	if c.ProvisionalDeviation == 0 {
+		c.ProvisionalDeviation = DefaultProvisionalDeviation
+	}
+	return c
+}
+
+func (c Config) volatility(sigma, variance, phi, delta float64) float64 {
 	var A, B, C, fa, fb, fc float64
 	a := toAlpha(sigma)
-	A, B = initializeComparison(sigma, variance, phi, delta, a)
-	fa = illinois(A, phi, variance, a, delta)
-	fb = illinois(B, phi, variance, a, delta)
-	for math.Abs(B-A) > ConverganceTolerance {
+	A, B = c.initializeComparison(sigma, variance, phi, delta, a)
+	fa = c.illinois(A, phi, variance, a, delta)
+	fb = c.illinois(B, phi, variance, a, delta)
+	for math.Abs(B-A) > c.ConvergenceTolerance {
 		C = A + (A-B)*fa/(fb-fa)
-		fc = illinois(C, phi, variance, a, delta)
+		fc = c.illinois(C, phi, variance, a, delta)
 		if 0 > (fc * fb) {
 			A = B
 			fa = fb
@@ -164,7 +260,7 @@ func volatility(sigma, variance, phi, delta float64) float64 {
 	return math.Pow(math.E, (A / 2))
 }
 
-func initializeComparison(sigma, variance, phi, delta, a float64) (float64, float64) {
+func (c Config) initializeComparison(sigma, variance, phi, delta, a float64) (float64, float64) {
 	var A, B float64
 	A = a
 	deltaSquared := math.Pow(delta, 2)
@@ -173,19 +269,19 @@ func initializeComparison(sigma, variance, phi, delta, a float64) (float64, floa
 		return A, B
 	}
 	k := 1.0
-	for 0 > illinois(a-k*SystemConstant, phi, variance, a, delta) {
+	for 0 > c.illinois(a-k*c.SystemConstant, phi, variance, a, delta) {
 		k++
 	}
-	B = a - k*SystemConstant
+	B = a - k*c.SystemConstant
 	return A, B
 }
 
 // The Illinois algorithm is a variant of the regula falsi (false position) procedure. The Illinois algorithm is quite stable, reliable, and converges quickly. The algorithm takes advantage of the knowledge that the desired value of σ′ can be sandwiched at the start of the algorithm by the initial choices of A and B.
-func illinois(x, phi, variance, alpha, delta float64) float64 {
+func (c Config) illinois(x, phi, variance, alpha, delta float64) float64 {
 	ex := math.Pow(math.E, x)
 	phiSquared := math.Pow(phi, 2)
 	left := ex * (math.Pow(delta, 2) - phiSquared - variance - ex) / (2 * math.Pow(phiSquared+variance+ex, 2))
-	right := (x - alpha) / math.Pow(SystemConstant, 2)
+	right := (x - alpha) / math.Pow(c.SystemConstant, 2)
 	return left - right
 }
 
@@ -233,30 +329,30 @@ func totalResultScore(history *[]Result) float64 {
 	return ts
 }
 
-func toPhi(deviation float64) float64 {
-	return deviation / 173.7178
+func (c Config) toPhi(deviation float64) float64 {
+	return deviation / c.Scale
 }
 
-func toMu(rating float64) float64 {
-	return (rating - 1500) / 173.7178
+func (c Config) toMu(rating float64) float64 {
+	return (rating - c.Center) / c.Scale
 }
 
-func toG(deviation float64) float64 {
-	return 1 / math.Sqrt(1+(3*math.Pow(toPhi(deviation), 2)/math.Pow(math.Pi, 2)))
+func (c Config) toG(deviation float64) float64 {
+	return 1 / math.Sqrt(1+(3*math.Pow(c.toPhi(deviation), 2)/math.Pow(math.Pi, 2)))
 }
 
-func toE(playerRating, opponentRating, opponentG float64) float64 {
-	return 1 / (1 + math.Pow(math.E, -opponentG*(toMu(playerRating)-toMu(opponentRating))))
+func (c Config) toE(playerRating, opponentRating, opponentG float64) float64 {
+	return 1 / (1 + math.Pow(math.E, -opponentG*(c.toMu(playerRating)-c.toMu(opponentRating))))
 }
 
 func toAlpha(sigma float64) float64 {
 	return math.Log(math.Pow(sigma, 2))
 }
 
-func fromMu(mu float64) float64 {
-	return (173.7178 * mu) + 1500
+func (c Config) fromMu(mu float64) float64 {
+	return (c.Scale * mu) + c.Center
 }
 
-func fromPhi(phi float64) float64 {
-	return 173.7178 * phi
+func (c Config) fromPhi(phi float64) float64 {
+	return c.Scale * phi
 }
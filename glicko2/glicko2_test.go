@@ -3,6 +3,8 @@ package glicko2
 import (
 	"math"
 	"testing"
+
+	"github.com/dylrich/rating/internal/simulate"
 )
 
 var (
@@ -23,22 +25,23 @@ var (
 		InitialDeviation: 300,
 		InitialRating:    1700,
 	})
+	c = Config{SystemConstant: 0.5}.withDefaults()
 )
 
 func TestToMu(t *testing.T) {
-	mu := toMu(1400)
+	mu := c.toMu(1400)
 	if math.Abs(mu - -0.5756) > .0001 {
 		t.Log(mu)
 		t.Fail()
 	}
 
-	mu = toMu(1550)
+	mu = c.toMu(1550)
 	if math.Abs(mu-.2878) > .0001 {
 		t.Log(mu)
 		t.Fail()
 	}
 
-	mu = toMu(1700)
+	mu = c.toMu(1700)
 	if math.Abs(mu-1.1513) > .0001 {
 		t.Log(mu)
 		t.Fail()
@@ -47,19 +50,19 @@ func TestToMu(t *testing.T) {
 }
 
 func TestToPhi(t *testing.T) {
-	phi := toPhi(30)
+	phi := c.toPhi(30)
 	if math.Abs(phi-.1727) > .0001 {
 		t.Log(phi)
 		t.Fail()
 	}
 
-	phi = toPhi(100)
+	phi = c.toPhi(100)
 	if math.Abs(phi-.5756) > .0001 {
 		t.Log(phi)
 		t.Fail()
 	}
 
-	phi = toPhi(300)
+	phi = c.toPhi(300)
 	if math.Abs(phi-1.7269) > .0001 {
 		t.Log(phi)
 		t.Fail()
@@ -67,19 +70,19 @@ func TestToPhi(t *testing.T) {
 }
 
 func TestToG(t *testing.T) {
-	g := toG(30.0)
+	g := c.toG(30.0)
 	if math.Abs(g-.9955) > .0001 {
 		t.Log(g)
 		t.Fail()
 	}
 
-	g = toG(100.0)
+	g = c.toG(100.0)
 	if math.Abs(g-.9531) > .0001 {
 		t.Log(g)
 		t.Fail()
 	}
 
-	g = toG(300.0)
+	g = c.toG(300.0)
 	if math.Abs(g-.7242) > .0001 {
 		t.Log(g)
 		t.Fail()
@@ -87,19 +90,19 @@ func TestToG(t *testing.T) {
 }
 
 func TestToE(t *testing.T) {
-	e := toE(1500, 1400, .9955)
+	e := c.toE(1500, 1400, .9955)
 	if math.Abs(e-.639) > .001 {
 		t.Log(e)
 		t.Fail()
 	}
 
-	e = toE(1500, 1550, .9531)
+	e = c.toE(1500, 1550, .9531)
 	if math.Abs(e-.432) > .001 {
 		t.Log(e)
 		t.Fail()
 	}
 
-	e = toE(1500, 1700, .7242)
+	e = c.toE(1500, 1700, .7242)
 	if math.Abs(e-.303) > .001 {
 		t.Log(e)
 		t.Fail()
@@ -144,9 +147,9 @@ func TestImpact(t *testing.T) {
 
 func TestTotalImpact(t *testing.T) {
 	p1.Reset()
-	p1.addResult(p2.Rating, p2.Deviation, 1)
-	p1.addResult(p3.Rating, p3.Deviation, 0)
-	p1.addResult(p4.Rating, p4.Deviation, 0)
+	p1.addResult(p2.Rating, p2.Deviation, 1, 0)
+	p1.addResult(p3.Rating, p3.Deviation, 0, 0)
+	p1.addResult(p4.Rating, p4.Deviation, 0, 0)
 	ti := totalImpact(&p1.History)
 
 	if math.Abs(ti-0.5621) > .0001 {
@@ -157,9 +160,9 @@ func TestTotalImpact(t *testing.T) {
 
 func TestTotalResultScore(t *testing.T) {
 	p1.Reset()
-	p1.addResult(p2.Rating, p2.Deviation, 1)
-	p1.addResult(p3.Rating, p3.Deviation, 0)
-	p1.addResult(p4.Rating, p4.Deviation, 0)
+	p1.addResult(p2.Rating, p2.Deviation, 1, 0)
+	p1.addResult(p3.Rating, p3.Deviation, 0, 0)
+	p1.addResult(p4.Rating, p4.Deviation, 0, 0)
 	rs := totalResultScore(&p1.History)
 	if math.Abs(rs - -0.2720) > .0001 {
 		t.Log(rs)
@@ -186,20 +189,19 @@ func TestDelta(t *testing.T) {
 }
 
 func TestIllinois(t *testing.T) {
-	SystemConstant = 0.5
 	phi := 1.1513
 	variance := 1.7785
 	delta := -0.4834
 	a := -5.62682
 	A := -5.62682
 	B := -6.12682
-	ia := illinois(A, phi, variance, a, delta)
+	ia := c.illinois(A, phi, variance, a, delta)
 	if math.Abs(ia - -0.00053567) > .00000001 {
 		t.Log(ia)
 		t.Fail()
 	}
 
-	ib := illinois(B, phi, variance, a, delta)
+	ib := c.illinois(B, phi, variance, a, delta)
 	if math.Abs(ib-1.999675) > .000001 {
 		t.Log(ib)
 		t.Fail()
@@ -207,13 +209,12 @@ func TestIllinois(t *testing.T) {
 }
 
 func TestInitialize(t *testing.T) {
-	SystemConstant = 0.5
 	sigma := 0.06
 	phi := 1.1513
 	variance := 1.7785
 	delta := -0.4834
 	a := -5.62682
-	A, B := initializeComparison(sigma, variance, phi, delta, a)
+	A, B := c.initializeComparison(sigma, variance, phi, delta, a)
 	if math.Abs(A - -5.62682) > .00001 {
 		t.Log(A)
 		t.Fail()
@@ -226,12 +227,11 @@ func TestInitialize(t *testing.T) {
 }
 
 func TestVolatility(t *testing.T) {
-	SystemConstant = 0.5
 	sigma := 0.06
 	phi := 1.1513
 	variance := 1.7785
 	delta := -0.4834
-	v := volatility(sigma, variance, phi, delta)
+	v := c.volatility(sigma, variance, phi, delta)
 	if math.Abs(v-0.05999) > .00001 {
 		t.Log(v)
 		t.Fail()
@@ -262,7 +262,7 @@ func TestMuPrime(t *testing.T) {
 
 func TestFromMu(t *testing.T) {
 	mu := -0.2069
-	rating := fromMu(mu)
+	rating := c.fromMu(mu)
 	if math.Abs(rating-1464.06) > .01 {
 		t.Log(rating)
 		t.Fail()
@@ -271,7 +271,7 @@ func TestFromMu(t *testing.T) {
 
 func TestFromPhi(t *testing.T) {
 	phi := 0.8722
-	deviation := fromPhi(phi)
+	deviation := c.fromPhi(phi)
 	if math.Abs(deviation-151.5) > .1 {
 		t.Log(deviation)
 		t.Fail()
@@ -280,7 +280,7 @@ func TestFromPhi(t *testing.T) {
 
 func TestGlicko2(t *testing.T) {
 	p1.Reset()
-	SystemConstant = 0.5
+	p1.Config = c
 	p1.Win(p2.Rating, p2.Deviation)
 	p1.Lose(p3.Rating, p3.Deviation)
 	outcome := p1.Lose(p4.Rating, p4.Deviation)
@@ -306,3 +306,84 @@ func BenchmarkGlicko2(b *testing.B) {
 		p1.Win(p2.Rating, p2.Deviation)
 	}
 }
+
+func TestConfigCenter(t *testing.T) {
+	shifted := Config{SystemConstant: 0.5, Center: 1000}
+	p := shifted.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1000, InitialVolatility: 0.06})
+	p.Win(p2.Rating-500, p2.Deviation)
+	p.Lose(p3.Rating-500, p3.Deviation)
+	outcome := p.Lose(p4.Rating-500, p4.Deviation)
+	if math.Abs(outcome.Rating-964.06) > .01 || math.Abs(outcome.Deviation-151.52) > .01 {
+		t.Log(outcome)
+		t.Fail()
+	}
+}
+
+func TestIdle(t *testing.T) {
+	p := NewPlayer(Parameters{InitialDeviation: 50, InitialRating: 1600, InitialVolatility: 0.06})
+	outcome := p.getOutcome()
+	if outcome.Rating != 1600 || outcome.Volatility != 0.06 || math.Abs(outcome.Deviation-51.07) > .01 {
+		t.Log(outcome)
+		t.Fail()
+	}
+
+	p.NewPeriod()
+	if p.Rating != 1600 || p.Volatility != 0.06 || p.Deviation != outcome.Deviation || p.Parameters.InitialDeviation != p.Deviation {
+		t.Log(p)
+		t.Fail()
+	}
+
+	p.NewPeriods(0)
+	if p.Deviation != outcome.Deviation {
+		t.Log(p)
+		t.Fail()
+	}
+
+	p.NewPeriods(3)
+	phi := p.Config.toPhi(50)
+	expected := p.Config.fromPhi(math.Sqrt(phi*phi + 4*0.06*0.06))
+	if math.Abs(p.Deviation-expected) > 1e-9 {
+		t.Log(p.Deviation, expected)
+		t.Fail()
+	}
+}
+
+func TestNewPeriodAfterResults(t *testing.T) {
+	p := c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06})
+	p.Win(p2.Rating, p2.Deviation)
+	p.Lose(p3.Rating, p3.Deviation)
+	outcome := p.Lose(p4.Rating, p4.Deviation)
+	p.NewPeriod()
+	if p.Deviation != outcome.Deviation || p.Rating != outcome.Rating || len(p.History) != 0 {
+		t.Log(p)
+		t.Fail()
+	}
+
+	p.NewPeriods(2)
+	if p.Deviation <= outcome.Deviation {
+		t.Log(p)
+		t.Fail()
+	}
+}
+
+func TestPlay(t *testing.T) {
+	league := Config{SystemConstant: 0.5, Advantage: 50}
+	home := league.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1450, InitialVolatility: 0.06})
+	neutral := league.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06})
+	ho := home.Play(1400, 30, 1, 1)
+	no := neutral.Win(1400, 30)
+	if math.Abs(ho.RatingDelta-no.RatingDelta) > 1e-9 || math.Abs(ho.Deviation-no.Deviation) > 1e-9 || home.History[0].Advantage != 50 {
+		t.Log(ho, no)
+		t.Fail()
+	}
+}
+
+func TestFitAdvantage(t *testing.T) {
+	games := simulate.League{Players: 30, Periods: 40, GamesPerPeriod: 40, Drift: 10, Advantage: 60}.Games(1)
+	params := Parameters{InitialDeviation: DefaultInitialDeviation, InitialRating: DefaultInitialRating, InitialVolatility: DefaultInitialVolatility}
+	fitted := DefaultConfig().FitAdvantage(params, games)
+	if math.Abs(fitted.Advantage-60) > 15 {
+		t.Log(fitted)
+		t.Fail()
+	}
+}
@@ -0,0 +1,35 @@
+package glicko2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatePeriod(t *testing.T) {
+	a := c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06})
+	b := c.NewPlayer(Parameters{InitialDeviation: 30, InitialRating: 1400, InitialVolatility: 0.06})
+	d := c.NewPlayer(Parameters{InitialDeviation: 100, InitialRating: 1550, InitialVolatility: 0.06})
+	e := c.NewPlayer(Parameters{InitialDeviation: 300, InitialRating: 1700, InitialVolatility: 0.06})
+	idle := c.NewPlayer(Parameters{InitialDeviation: 50, InitialRating: 1500, InitialVolatility: 0.06})
+	outcomes := RatePeriod([]*Player{a, idle}, []Match{
+		{Player: a, Opponent: b, Score: 1},
+		{Player: d, Opponent: a, Score: 1},
+		{Player: a, Opponent: e, Score: 0},
+	})
+
+	if math.Abs(outcomes[a].Rating-1464.06) > .01 || math.Abs(outcomes[a].Deviation-151.52) > .01 || math.Abs(outcomes[a].Volatility-.05999) > .00001 {
+		t.Log(outcomes[a])
+		t.Fail()
+	}
+
+	expected := c.NewPlayer(Parameters{InitialDeviation: 30, InitialRating: 1400, InitialVolatility: 0.06}).Lose(1500, 200)
+	if math.Abs(outcomes[b].Rating-expected.Rating) > 1e-9 || len(outcomes) != 5 {
+		t.Log(outcomes[b], expected)
+		t.Fail()
+	}
+
+	if outcomes[idle].Rating != 1500 || math.Abs(outcomes[idle].Deviation-51.07) > .01 || idle.Parameters.InitialDeviation != idle.Deviation {
+		t.Log(outcomes[idle])
+		t.Fail()
+	}
+}
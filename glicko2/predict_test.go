@@ -0,0 +1,40 @@
+package glicko2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	// With no uncertainty, Glicko2's expected score is a logistic curve in μ.
+	if e := c.Expected(1500+c.Scale, 0, 1500, 0); math.Abs(e-1/(1+math.Exp(-1))) > 0.000001 {
+		t.Log(e)
+		t.Fail()
+	}
+
+	// The combined deviation of 30 and 40 is 50.
+	if e, g := c.Expected(1700, 30, 1500, 40), c.toG(50); math.Abs(e-1/(1+math.Exp(-g*200/c.Scale))) > 0.000001 {
+		t.Log(e)
+		t.Fail()
+	}
+	if certain, uncertain := c.Expected(1700, 50, 1500, 50), c.Expected(1700, 300, 1500, 300); uncertain >= certain || uncertain <= 0.5 {
+		t.Log(certain, uncertain)
+		t.Fail()
+	}
+
+	p := c.NewPlayer(Parameters{InitialRating: 1700, InitialDeviation: 30, InitialVolatility: 0.06})
+	if e := p.Expected(1500, 40); e != c.Expected(1700, 30, 1500, 40) {
+		t.Log(e)
+		t.Fail()
+	}
+}
+
+func TestGap(t *testing.T) {
+	for _, probability := range []float64{0.1, 0.5, 0.75, 0.99} {
+		gap := c.Gap(probability, 80, 200)
+		if e := c.Expected(1500+gap, 80, 1500, 200); math.Abs(e-probability) > 0.000001 {
+			t.Log(probability, e)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package glicko2
+
+import (
+	"github.com/dylrich/rating"
+)
+
+// Interval returns the range that contains the calling Player's true rating with the given probability, as described for rating.Estimate.Interval.
+func (p *Player) Interval(level float64) (float64, float64) {
+	return p.estimate().Interval(level)
+}
+
+// Conservative returns a conservative display rating of Rating - k·Deviation, as described for rating.Estimate.Conservative.
+func (p *Player) Conservative(k float64) float64 {
+	return p.estimate().Conservative(k)
+}
+
+// Provisional reports whether the calling Player's Deviation is above Config.ProvisionalDeviation, in which case their rating should not yet be relied on.
+func (p *Player) Provisional() bool {
+	return p.estimate().Provisional(p.Config.ProvisionalDeviation)
+}
+
+// ProbabilityStronger returns the probability that the calling Player's true rating is higher than that of an opponent with the given rating and deviation, as described for rating.Estimate.ProbabilityStronger.
+func (p *Player) ProbabilityStronger(opponentRating, opponentDeviation float64) float64 {
+	return p.estimate().ProbabilityStronger(rating.Estimate{Rating: opponentRating, Deviation: opponentDeviation})
+}
+
+func (p *Player) estimate() rating.Estimate {
+	return rating.Estimate{Rating: p.Rating, Deviation: p.Deviation}
+}
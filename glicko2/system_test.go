@@ -0,0 +1,24 @@
+package glicko2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestSystem(t *testing.T) {
+	var s rating.System = System{Config: Config{SystemConstant: 0.5}, Parameters: Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06}}
+	a := s.NewPlayer()
+	a.Win(rating.Estimate{Rating: p2.Rating, Deviation: p2.Deviation})
+	a.Lose(rating.Estimate{Rating: p3.Rating, Deviation: p3.Deviation})
+	outcome := a.Lose(rating.Estimate{Rating: p4.Rating, Deviation: p4.Deviation})
+	if math.Abs(outcome.Rating-1464.06) > .01 || math.Abs(outcome.Deviation-151.52) > .01 || math.Abs(outcome.Volatility-.05999) > .00001 {
+		t.Log(outcome)
+		t.Fail()
+	}
+	if a.Estimate() != outcome.Estimate() {
+		t.Log(a.Estimate(), outcome)
+		t.Fail()
+	}
+}
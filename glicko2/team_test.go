@@ -0,0 +1,49 @@
+package glicko2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestRateTeams(t *testing.T) {
+	single := c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06})
+	expected := single.Win(1500, 200)
+	a := []Member{{Player: c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06})}}
+	b := []Member{{Player: c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06})}}
+	ao, _ := RateTeams(a, b, 1, rating.Sum)
+	if math.Abs(ao[0].Rating-expected.Rating) > 1e-9 || math.Abs(ao[0].Deviation-expected.Deviation) > 1e-9 || math.Abs(ao[0].Volatility-expected.Volatility) > 1e-9 {
+		t.Log(ao, expected)
+		t.Fail()
+	}
+
+	starter := c.NewPlayer(Parameters{InitialDeviation: 150, InitialRating: 1500, InitialVolatility: 0.06})
+	substitute := c.NewPlayer(Parameters{InitialDeviation: 150, InitialRating: 1500, InitialVolatility: 0.06})
+	a = []Member{{Player: starter, Playtime: 40}, {Player: substitute, Playtime: 8}}
+	b = []Member{{Player: c.NewPlayer(Parameters{InitialDeviation: 150, InitialRating: 1500, InitialVolatility: 0.06}), Playtime: 24}}
+	ao, bo := RateTeams(a, b, 1, rating.Playtime)
+	if ao[0].RatingDelta <= ao[1].RatingDelta || ao[1].RatingDelta <= 0 || bo[0].RatingDelta >= 0 {
+		t.Log(ao, bo)
+		t.Fail()
+	}
+
+	// A team result is rated together with the rest of the period instead of closing it.
+	p := c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06})
+	p.Win(1400, 50)
+	parameters := p.Parameters
+	RateTeams([]Member{{Player: p}}, []Member{{Player: c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500, InitialVolatility: 0.06})}}, 0, rating.Mean)
+	if len(p.History) != 2 || p.Parameters != parameters {
+		t.Log(p)
+		t.Fail()
+	}
+
+	// Players without any volatility keep none.
+	a = []Member{{Player: c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})}}
+	b = []Member{{Player: c.NewPlayer(Parameters{InitialDeviation: 200, InitialRating: 1500})}}
+	ao, _ = RateTeams(a, b, 1, rating.Mean)
+	if math.IsNaN(ao[0].Rating) || math.IsNaN(ao[0].Volatility) || ao[0].RatingDelta <= 0 {
+		t.Log(ao)
+		t.Fail()
+	}
+}
@@ -0,0 +1,70 @@
+package glicko2
+
+import (
+	"math"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/league"
+	"github.com/dylrich/rating/internal/optimize"
+)
+
+// Evaluation reports how well a SystemConstant and InitialVolatility predicted a historical dataset. LogLoss and BrierScore are averaged over every game in the dataset, and lower values are better for both.
+type Evaluation struct {
+	SystemConstant, InitialVolatility, LogLoss, BrierScore float64
+}
+
+// Evaluate replays a historical dataset using the calling Config and reports the accuracy of its predictions. The games must be in chronological order and are replayed one rating period at a time using RatePeriod, with every player starting from the given Parameters. Each game is predicted from both players' ratings before its rating period.
+func (c Config) Evaluate(p Parameters, games []rating.Game) Evaluation {
+	c = c.withDefaults()
+	e := Evaluation{SystemConstant: c.SystemConstant, InitialVolatility: p.InitialVolatility}
+	if len(games) == 0 {
+		return e
+	}
+	league.Replay[*Player]{
+		NewPlayer: func() *Player { return c.NewPlayer(p) },
+		Predict: func(a, b *Player, g rating.Game) {
+			expected := c.Expected(a.Rating+g.Advantage*c.Advantage, a.Deviation, b.Rating, b.Deviation)
+			e.LogLoss += rating.LogLoss(expected, g.Score)
+			e.BrierScore += rating.BrierScore(expected, g.Score)
+		},
+		RatePeriod: ratePeriod,
+		Skip:       skip,
+	}.Run(games)
+	e.LogLoss /= float64(len(games))
+	e.BrierScore /= float64(len(games))
+	return e
+}
+
+// Tune evaluates every combination of the given system constants and initial volatilities against a historical dataset, as described for Evaluate, and returns the Evaluation with the lowest LogLoss along with every Evaluation in the order they were run. If volatilities is empty, only p.InitialVolatility is tried. A system constant of 0 is evaluated as DefaultSystemConstant, as described for Config.SystemConstant. The best SystemConstant and InitialVolatility can be copied directly into a Config and Parameters.
+func (c Config) Tune(p Parameters, games []rating.Game, systemConstants, volatilities []float64) (Evaluation, []Evaluation) {
+	if len(volatilities) == 0 {
+		volatilities = []float64{p.InitialVolatility}
+	}
+	best := Evaluation{LogLoss: math.Inf(1)}
+	evaluations := make([]Evaluation, 0, len(systemConstants)*len(volatilities))
+	for _, tau := range systemConstants {
+		for _, sigma := range volatilities {
+			trial := c
+			trial.SystemConstant = tau
+			params := p
+			params.InitialVolatility = sigma
+			e := trial.Evaluate(params, games)
+			if e.LogLoss < best.LogLoss {
+				best = e
+			}
+			evaluations = append(evaluations, e)
+		}
+	}
+	return best, evaluations
+}
+
+// FitAdvantage returns a copy of the Config with Advantage chosen to minimise the mean log loss of predicting each game in a historical dataset, replayed as described for Evaluate. Only games with a non-zero Advantage carry information about it.
+func (c Config) FitAdvantage(p Parameters, games []rating.Game) Config {
+	c = c.withDefaults()
+	c.Advantage = optimize.Golden(func(x float64) float64 {
+		trial := c
+		trial.Advantage = x
+		return trial.Evaluate(p, games).LogLoss
+	}, -2*c.Scale, 2*c.Scale, 0.01)
+	return c
+}
@@ -0,0 +1,47 @@
+package glicko2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/simulate"
+)
+
+func TestEvaluate(t *testing.T) {
+	games := []rating.Game{
+		{Period: 0, Player: "a", Opponent: "b", Score: 1},
+		{Period: 2, Player: "a", Opponent: "b", Score: 0},
+	}
+	e := DefaultConfig().Evaluate(Parameters{InitialDeviation: 350, InitialRating: 1500, InitialVolatility: 0.06}, games)
+	if e.SystemConstant != DefaultSystemConstant || e.LogLoss <= math.Ln2/2 || e.BrierScore <= 0.125 {
+		t.Log(e)
+		t.Fail()
+	}
+}
+
+func TestTune(t *testing.T) {
+	games := simulate.League{Players: 30, Periods: 30, GamesPerPeriod: 20, Drift: 30}.Games(1)
+	params := Parameters{InitialDeviation: DefaultInitialDeviation, InitialRating: DefaultInitialRating, InitialVolatility: DefaultInitialVolatility}
+	best, evaluations := DefaultConfig().Tune(params, games, []float64{0.3, 0.6, 1.2}, []float64{0.03, 0.06, 0.09})
+	if len(evaluations) != 9 {
+		t.Log(evaluations)
+		t.FailNow()
+	}
+	for _, e := range evaluations {
+		if e.LogLoss < best.LogLoss || e.BrierScore <= 0 || e.BrierScore >= 0.25 {
+			t.Log(best, e)
+			t.Fail()
+		}
+	}
+	if evaluations[4].SystemConstant != 0.6 || evaluations[4].InitialVolatility != 0.06 {
+		t.Log(evaluations[4])
+		t.Fail()
+	}
+
+	best, evaluations = DefaultConfig().Tune(params, games, []float64{0.5}, nil)
+	if len(evaluations) != 1 || best != evaluations[0] || best.InitialVolatility != DefaultInitialVolatility {
+		t.Log(best, evaluations)
+		t.Fail()
+	}
+}
@@ -0,0 +1,71 @@
+// Package league contains the handling of leagues of players shared by the rating system packages, such as replaying a historical dataset for the evaluation and calibration utilities.
+package league
+
+import (
+	"github.com/dylrich/rating"
+)
+
+// Replay is a replay of a historical dataset through a rating system whose players are of type P.
+type Replay[P any] struct {
+
+	// NewPlayer creates a player the first time their ID appears in the games.
+	NewPlayer func() P
+
+	// Predict is called for every game, in order, with both players in their state before the game's rating period.
+	Predict func(player, opponent P, g rating.Game)
+
+	// RatePeriod rates the games of a rating period between the given players, where players[i] played g[i].Player and opponents[i] played g[i].Opponent. The league holds every player seen so far, in the order they first appeared.
+	RatePeriod func(league []P, games []rating.Game, players, opponents []P)
+
+	// Skip advances every player in the league by the given number of rating periods without games. It is called for gaps between the Periods of consecutive games, and may be nil if the system does not track inactivity.
+	Skip func(league []P, periods int)
+}
+
+// Run replays the games, which must be in chronological order, one rating period at a time.
+func (r Replay[P]) Run(games []rating.Game) {
+	ids := make(map[string]P)
+	var league []P
+	get := func(id string) P {
+		if player, ok := ids[id]; ok {
+			return player
+		}
+		player := r.NewPlayer()
+		ids[id] = player
+		league = append(league, player)
+		return player
+	}
+	for i := 0; i < len(games); {
+		start := i
+		var players, opponents []P
+		for ; i < len(games) && games[i].Period == games[start].Period; i++ {
+			a, b := get(games[i].Player), get(games[i].Opponent)
+			r.Predict(a, b, games[i])
+			players, opponents = append(players, a), append(opponents, b)
+		}
+		r.RatePeriod(league, games[start:i], players, opponents)
+		if r.Skip != nil && i < len(games) && games[i].Period > games[start].Period+1 {
+			r.Skip(league, games[i].Period-games[start].Period-1)
+		}
+	}
+}
+
+// Participants returns the players followed by every other player that appears in the matches, without duplicates, in the order they first appear. sides returns the two players of a match.
+func Participants[P comparable, M any](players []P, matches []M, sides func(m M) (P, P)) []P {
+	seen := make(map[P]bool, len(players))
+	all := make([]P, 0, len(players))
+	add := func(p P) {
+		if !seen[p] {
+			seen[p] = true
+			all = append(all, p)
+		}
+	}
+	for _, p := range players {
+		add(p)
+	}
+	for _, m := range matches {
+		a, b := sides(m)
+		add(a)
+		add(b)
+	}
+	return all
+}
@@ -0,0 +1,56 @@
+package league
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+type player struct {
+	id    int
+	games int
+}
+
+func TestRun(t *testing.T) {
+	games := []rating.Game{
+		{Period: 0, Player: "a", Opponent: "b"},
+		{Period: 0, Player: "b", Opponent: "c"},
+		{Period: 3, Player: "c", Opponent: "a"},
+	}
+	created := 0
+	var predicted, periods, skipped []int
+	Replay[*player]{
+		NewPlayer: func() *player {
+			created++
+			return &player{id: created}
+		},
+		Predict: func(a, b *player, g rating.Game) {
+			// Every game is predicted before its period is rated.
+			predicted = append(predicted, a.games, b.games)
+		},
+		RatePeriod: func(league []*player, games []rating.Game, players, opponents []*player) {
+			periods = append(periods, len(league), len(games))
+			for i := range games {
+				players[i].games++
+				opponents[i].games++
+			}
+		},
+		Skip: func(league []*player, n int) {
+			skipped = append(skipped, n)
+		},
+	}.Run(games)
+	if created != 3 || !reflect.DeepEqual(predicted, []int{0, 0, 0, 0, 1, 1}) || !reflect.DeepEqual(periods, []int{3, 2, 3, 1}) || !reflect.DeepEqual(skipped, []int{2}) {
+		t.Log(created, predicted, periods, skipped)
+		t.Fail()
+	}
+}
+
+func TestParticipants(t *testing.T) {
+	matches := [][2]string{{"b", "c"}, {"d", "a"}}
+	all := Participants([]string{"a"}, matches, func(m [2]string) (string, string) { return m[0], m[1] })
+	if !reflect.DeepEqual(all, []string{"a", "b", "c", "d"}) {
+		t.Log(all)
+		t.Fail()
+	}
+}
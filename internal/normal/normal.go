@@ -0,0 +1,87 @@
+// Package normal contains the standard normal distribution functions, truncated Gaussian corrections, and performance-based predictions shared by the trueskill and openskill packages.
+package normal
+
+import (
+	"math"
+)
+
+// PDF returns the probability density of the standard normal distribution at x.
+func PDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+// CDF returns the probability that a standard normal variable is at most x.
+func CDF(x float64) float64 {
+	return math.Erfc(-x/math.Sqrt2) / 2
+}
+
+// PPF returns the x at which CDF is p, which is the inverse of CDF.
+func PPF(p float64) float64 {
+	return -math.Sqrt2 * math.Erfcinv(2*p)
+}
+
+// VWin and WWin are the additive and multiplicative corrections to the mean and variance of a performance difference x that is known to be greater than the margin t. When the chance of that is too small to represent, they fall back to their limits.
+func VWin(x, t float64) float64 {
+	xt := x - t
+	denom := CDF(xt)
+	if denom < math.SmallestNonzeroFloat64 {
+		return -xt
+	}
+	return PDF(xt) / denom
+}
+
+// WWin is described with VWin.
+func WWin(x, t float64) float64 {
+	xt := x - t
+	denom := CDF(xt)
+	if denom < math.SmallestNonzeroFloat64 {
+		if x < 0 {
+			return 1
+		}
+		return 0
+	}
+	v := VWin(x, t)
+	return v * (v + xt)
+}
+
+// VDraw and WDraw are the additive and multiplicative corrections to the mean and variance of a performance difference x that is known to be within the margin t. When the chance of that is too small to represent, they fall back to their limits.
+func VDraw(x, t float64) float64 {
+	abs := math.Abs(x)
+	b := CDF(t-abs) - CDF(-t-abs)
+	if b < 1e-5 {
+		if x < 0 {
+			return -x - t
+		}
+		return -x + t
+	}
+	a := PDF(-t-abs) - PDF(t-abs)
+	if x < 0 {
+		return -a / b
+	}
+	return a / b
+}
+
+// WDraw is described with VDraw.
+func WDraw(x, t float64) float64 {
+	abs := math.Abs(x)
+	b := CDF(t-abs) - CDF(-t-abs)
+	if b < math.SmallestNonzeroFloat64 {
+		return 1
+	}
+	return ((t-abs)*PDF(t-abs)+(t+abs)*PDF(-t-abs))/b + math.Pow(VDraw(x, t), 2)
+}
+
+// Spread returns the standard deviation of the difference between two players' performances, where each performance is drawn around the player's rating with the given deviation and an extra performance deviation beta.
+func Spread(beta, deviation, opponentDeviation float64) float64 {
+	return math.Sqrt(2*math.Pow(beta, 2) + math.Pow(deviation, 2) + math.Pow(opponentDeviation, 2))
+}
+
+// Expected returns the probability that a player's performance exceeds their opponent's, with performances spread as described for Spread.
+func Expected(beta, rating, deviation, opponentRating, opponentDeviation float64) float64 {
+	return CDF((rating - opponentRating) / Spread(beta, deviation, opponentDeviation))
+}
+
+// Gap returns the rating difference at which Expected gives the higher rated player the given probability, which is the inverse of Expected.
+func Gap(beta, probability, deviation, opponentDeviation float64) float64 {
+	return PPF(probability) * Spread(beta, deviation, opponentDeviation)
+}
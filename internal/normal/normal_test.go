@@ -0,0 +1,71 @@
+package normal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCDF(t *testing.T) {
+	if c := CDF(0); c != 0.5 {
+		t.Log(c)
+		t.Fail()
+	}
+	if c := CDF(1.96); math.Abs(c-0.975) > 0.0001 {
+		t.Log(c)
+		t.Fail()
+	}
+	for _, p := range []float64{0.01, 0.3, 0.5, 0.9} {
+		if x := CDF(PPF(p)); math.Abs(x-p) > 1e-12 {
+			t.Log(p, x)
+			t.Fail()
+		}
+	}
+	if d := PDF(0); math.Abs(d-1/math.Sqrt(2*math.Pi)) > 1e-12 {
+		t.Log(d)
+		t.Fail()
+	}
+}
+
+func TestCorrections(t *testing.T) {
+	if v, w := VWin(0, 0), WWin(0, 0); math.Abs(v-0.797885) > 0.000001 || math.Abs(w-0.636620) > 0.000001 {
+		t.Log(v, w)
+		t.Fail()
+	}
+	if v, w := VDraw(0, 0.5), WDraw(0, 0.5); v != 0 || w <= 0 || w >= 1 {
+		t.Log(v, w)
+		t.Fail()
+	}
+	if v := VDraw(-1, 0.5); v != -VDraw(1, 0.5) {
+		t.Log(v)
+		t.Fail()
+	}
+
+	// Far outside the margin, the corrections fall back to their limits instead of dividing by zero.
+	if v, w := VWin(-100, 0), WWin(-100, 0); v != 100 || w != 1 {
+		t.Log(v, w)
+		t.Fail()
+	}
+	if v, w := VDraw(100, 0.5), WDraw(100, 0.5); v != -99.5 || w != 1 {
+		t.Log(v, w)
+		t.Fail()
+	}
+}
+
+func TestExpected(t *testing.T) {
+	// A gap of one standard deviation of the performance difference wins 84.13% of the time.
+	spread := Spread(4, 3, 3)
+	if math.Abs(spread-math.Sqrt(50)) > 1e-12 {
+		t.Log(spread)
+		t.Fail()
+	}
+	if e := Expected(4, 25+spread, 3, 25, 3); math.Abs(e-0.841345) > 0.000001 {
+		t.Log(e)
+		t.Fail()
+	}
+	for _, probability := range []float64{0.1, 0.5, 0.75, 0.99} {
+		if e := Expected(4, 25+Gap(4, probability, 2, 5), 2, 25, 5); math.Abs(e-probability) > 0.000001 {
+			t.Log(probability, e)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,28 @@
+// Package optimize contains the numerical routines shared by the calibration utilities in the rating system packages.
+package optimize
+
+import (
+	"math"
+)
+
+var invPhi = (math.Sqrt(5) - 1) / 2
+
+// Golden returns the x in [lo, hi] that minimises f, using a golden-section search that stops once the bracket is narrower than tol. f is assumed to be unimodal on the interval; if it is not, a local minimum is returned.
+func Golden(f func(x float64) float64, lo, hi, tol float64) float64 {
+	a, b := lo, hi
+	c := b - invPhi*(b-a)
+	d := a + invPhi*(b-a)
+	fc, fd := f(c), f(d)
+	for b-a > tol {
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b - invPhi*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + invPhi*(b-a)
+			fd = f(d)
+		}
+	}
+	return (a + b) / 2
+}
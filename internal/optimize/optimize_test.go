@@ -0,0 +1,20 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	x := Golden(func(x float64) float64 { return math.Pow(x-3.2, 2) }, 0, 10, 1e-6)
+	if math.Abs(x-3.2) > 1e-5 {
+		t.Log(x)
+		t.Fail()
+	}
+
+	x = Golden(func(x float64) float64 { return x }, 1, 5, 1e-6)
+	if math.Abs(x-1) > 1e-5 {
+		t.Log(x)
+		t.Fail()
+	}
+}
@@ -0,0 +1,47 @@
+// Package simulate generates synthetic historical games for testing the calibration utilities in the rating system packages.
+package simulate
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+
+	"github.com/dylrich/rating"
+)
+
+// League describes a synthetic league. Every player starts with a true skill drawn from a normal distribution around 1500 with a standard deviation of 200, and each period their skill drifts by a normally distributed amount with a standard deviation of Drift. Games are played between random pairs of players with a random side holding an Advantage worth the given number of rating points, and are decided using the Elo logistic curve on a 400 point scale.
+type League struct {
+	Players, Periods, GamesPerPeriod int
+	Drift, Advantage                 float64
+}
+
+// Games returns the chronological list of games played in the league, generated deterministically from the seed.
+func (l League) Games(seed int64) []rating.Game {
+	r := rand.New(rand.NewSource(seed))
+	skills := make([]float64, l.Players)
+	for i := range skills {
+		skills[i] = 1500 + r.NormFloat64()*200
+	}
+	var games []rating.Game
+	for period := 0; period < l.Periods; period++ {
+		for i := range skills {
+			skills[i] += r.NormFloat64() * l.Drift
+		}
+		for n := 0; n < l.GamesPerPeriod; n++ {
+			a, b := r.Intn(l.Players), r.Intn(l.Players)
+			if a == b {
+				continue
+			}
+			advantage := 1.0
+			if r.Intn(2) == 0 {
+				advantage = -1
+			}
+			score := 0.0
+			if r.Float64() < 1/(1+math.Pow(10, (skills[b]-skills[a]-advantage*l.Advantage)/400)) {
+				score = 1
+			}
+			games = append(games, rating.Game{Period: period, Player: strconv.Itoa(a), Opponent: strconv.Itoa(b), Score: score, Advantage: advantage})
+		}
+	}
+	return games
+}
@@ -0,0 +1,90 @@
+// Package team contains the composite rating calculations shared by the team match APIs in the rating system packages, including the results recorded for each member of a team by the Glicko-style packages.
+package team
+
+import (
+	"math"
+
+	"github.com/dylrich/rating"
+)
+
+// Composite returns the rating and deviation of the weighted sum of the members' ratings, treating each member's rating as an independent estimate.
+func Composite(weights, ratings, deviations []float64) (float64, float64) {
+	rating, variance := 0.0, 0.0
+	for i, w := range weights {
+		rating += w * ratings[i]
+		variance += math.Pow(w*deviations[i], 2)
+	}
+	return rating, math.Sqrt(variance)
+}
+
+// Distribute splits a change in a composite team rating and deviation between the members. Each member's share of the change is proportional to their weight and the variance of their own rating, so the least certain members move the most. It returns each member's rating delta and new deviation. Combining the rating deltas with the same weights reproduces ratingDelta exactly.
+func Distribute(weights, deviations []float64, ratingDelta, deviation, newDeviation float64) ([]float64, []float64) {
+	deltas := make([]float64, len(weights))
+	newDeviations := make([]float64, len(weights))
+	variance := math.Pow(deviation, 2)
+	if variance == 0 {
+		copy(newDeviations, deviations)
+		return deltas, newDeviations
+	}
+	reduction := (variance - math.Pow(newDeviation, 2)) / math.Pow(variance, 2)
+	for i, w := range weights {
+		covariance := w * math.Pow(deviations[i], 2)
+		deltas[i] = ratingDelta * covariance / variance
+		newDeviations[i] = math.Sqrt(math.Max(math.Pow(deviations[i], 2)-math.Pow(covariance, 2)*reduction, 0))
+	}
+	return deltas, newDeviations
+}
+
+// Member is a team member's share of a match, as used by rating.Playtime aggregation, along with their current Rating and Deviation and their Rating at the start of the rating period.
+type Member struct {
+	Playtime, Rating, InitialRating, Deviation float64
+}
+
+// Side is a team in a Glicko-style team match, combined into a composite rating and deviation.
+type Side struct {
+	weights, deviations, offsets []float64
+	rating, deviation            float64
+}
+
+// Result is the result of a team match for one member of a Side, recorded against the opposing composite.
+type Result struct {
+	// Rating and Deviation are those of the opposing composite.
+	Rating, Deviation float64
+
+	// Weight is the member's weight in their own composite, by which their G is scaled so that their rating moves in proportion to their share of it.
+	Weight float64
+
+	// Opposition is the deviation the member's G is calculated from, which is the opposing composite's deviation with the uncertainty in the ratings of the member's teammates added.
+	Opposition float64
+
+	// Team is the rating of the member's composite with the member at their rating from the start of the period, from which their expectation is calculated as for an individual result.
+	Team float64
+}
+
+// NewSide combines the members of a team into a composite using the given aggregation.
+func NewSide(members []Member, aggregation rating.Aggregation) Side {
+	playtimes := make([]float64, len(members))
+	ratings := make([]float64, len(members))
+	s := Side{deviations: make([]float64, len(members)), offsets: make([]float64, len(members))}
+	for i, m := range members {
+		playtimes[i] = m.Playtime
+		ratings[i] = m.Rating
+		s.deviations[i] = m.Deviation
+		s.offsets[i] = m.Rating - m.InitialRating
+	}
+	s.weights = aggregation.Weights(playtimes)
+	s.rating, s.deviation = Composite(s.weights, ratings, s.deviations)
+	return s
+}
+
+// Result returns the Result of the match for the member of the Side at index i.
+func (s Side) Result(i int, opponent Side) Result {
+	variance := math.Pow(opponent.deviation, 2) + math.Pow(s.deviation, 2) - math.Pow(s.weights[i]*s.deviations[i], 2)
+	return Result{
+		Rating:     opponent.rating,
+		Deviation:  opponent.deviation,
+		Weight:     s.weights[i],
+		Opposition: math.Sqrt(math.Max(variance, 0)),
+		Team:       s.rating - s.weights[i]*s.offsets[i],
+	}
+}
@@ -0,0 +1,55 @@
+package team
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestDistribute(t *testing.T) {
+	weights := []float64{0.5, 0.3, 0.2}
+	ratings := []float64{1500, 1600, 1700}
+	deviations := []float64{50, 100, 200}
+	r, d := Composite(weights, ratings, deviations)
+	if math.Abs(r-1570) > 1e-9 || math.Abs(d-math.Sqrt(625+900+1600)) > 1e-9 {
+		t.Log(r, d)
+		t.Fail()
+	}
+
+	deltas, newDeviations := Distribute(weights, deviations, 30, d, 40)
+	for i := range ratings {
+		ratings[i] += deltas[i]
+	}
+	nr, _ := Composite(weights, ratings, newDeviations)
+	if math.Abs(nr-1600) > 1e-9 || deltas[2] <= deltas[1] || deltas[1] <= deltas[0] {
+		t.Log(nr, deltas)
+		t.Fail()
+	}
+	for i := range deviations {
+		if newDeviations[i] >= deviations[i] {
+			t.Log(newDeviations)
+			t.Fail()
+		}
+	}
+}
+
+func TestSide(t *testing.T) {
+	a := NewSide([]Member{{Rating: 1550, InitialRating: 1500, Deviation: 30}, {Rating: 1600, InitialRating: 1600, Deviation: 40}}, rating.Mean)
+	b := NewSide([]Member{{Rating: 1400, InitialRating: 1400, Deviation: 60}}, rating.Sum)
+	r := a.Result(0, b)
+	if r.Rating != 1400 || r.Deviation != 60 || r.Weight != 0.5 || r.Team != 1550 {
+		t.Log(r)
+		t.Fail()
+	}
+
+	// The member is rated against the opposition and the uncertainty in their teammate's rating, but not their own.
+	if math.Abs(r.Opposition-math.Sqrt(3600+400)) > 1e-9 {
+		t.Log(r.Opposition)
+		t.Fail()
+	}
+	if r := b.Result(0, a); r.Rating != 1575 || r.Team != 1400 || math.Abs(r.Opposition-25) > 1e-9 {
+		t.Log(r)
+		t.Fail()
+	}
+}
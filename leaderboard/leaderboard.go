@@ -0,0 +1,140 @@
+// Package leaderboard ranks players from any of the rating systems in this module. Players are described by an Entry holding their rating.Estimate, so Elo, Glicko, Glicko2, and any other rating.Player can be ranked in the same way.
+package leaderboard
+
+import (
+	"sort"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+)
+
+// Entry is a player to be ranked. Games is the number of games the player has completed, which is used to hide players who have not played enough.
+type Entry struct {
+	ID       string
+	Estimate rating.Estimate
+	Games    int
+}
+
+// Key returns the score by which an Entry is ranked. Higher scores rank first.
+type Key func(e Entry) float64
+
+// Config contains the settings for a leaderboard. Any zero field is replaced with its package default when the Config is used, and the thresholds are disabled while they are left at zero.
+type Config struct {
+
+	// Key is the score by which players are ranked. The default is ByRating.
+	Key Key
+
+	// MinGames hides players who have completed fewer games.
+	MinGames int
+
+	// MaxDeviation hides players whose Deviation is higher, because their ratings are not yet reliable. Systems without a deviation, such as Elo, are never hidden by it.
+	MaxDeviation float64
+}
+
+// Standing is a ranked player. Rank uses standard competition ranking, so players with equal scores share a rank and the following rank is skipped, as in 1, 2, 2, 4. Percentile is the percentage of ranked players with a lower score, counting players with an equal score as half. Movement is the number of places the player has risen since the previous Standings, and is negative if they have fallen. New is true if the player was not ranked in the previous Standings, in which case Movement is zero.
+type Standing struct {
+	Entry
+	Rank              int
+	Score, Percentile float64
+	Movement          int
+	New               bool
+}
+
+// Standings is a ranked leaderboard, ordered from first to last. Players with equal scores are ordered by ID. Keep the Standings from the end of each rating period to report movement in the next.
+type Standings []Standing
+
+// ByRating ranks players by their rating.
+func ByRating(e Entry) float64 {
+	return e.Estimate.Rating
+}
+
+// Conservative ranks players by their rating less k times their deviation, which favours players whose ratings are well established.
+func Conservative(k float64) Key {
+	return func(e Entry) float64 {
+		return e.Estimate.Rating - k*e.Estimate.Deviation
+	}
+}
+
+// FromElo returns an Entry for an Elo player.
+func FromElo(id string, p *elo.Player) Entry {
+	return Entry{ID: id, Estimate: rating.Estimate{Rating: p.Rating}, Games: p.GamesPlayed}
+}
+
+// FromGlicko returns an Entry for a Glicko player. Glicko players do not count their games across rating periods, so the caller provides the number of games.
+func FromGlicko(id string, p *glicko.Player, games int) Entry {
+	return Entry{ID: id, Estimate: rating.Estimate{Rating: p.Rating, Deviation: p.Deviation}, Games: games}
+}
+
+// FromGlicko2 returns an Entry for a Glicko2 player. Glicko2 players do not count their games across rating periods, so the caller provides the number of games.
+func FromGlicko2(id string, p *glicko2.Player, games int) Entry {
+	return Entry{ID: id, Estimate: rating.Estimate{Rating: p.Rating, Deviation: p.Deviation, Volatility: p.Volatility}, Games: games}
+}
+
+// FromPlayer returns an Entry for any rating.Player, with the number of games provided by the caller.
+func FromPlayer(id string, p rating.Player, games int) Entry {
+	return Entry{ID: id, Estimate: p.Estimate(), Games: games}
+}
+
+// Rank ranks the entries, hiding any that fall below the Config's thresholds. Movement is measured against the previous Standings, which may be nil.
+func (c Config) Rank(entries []Entry, previous Standings) Standings {
+	c = c.withDefaults()
+	standings := Standings{}
+	for _, e := range entries {
+		if e.Games < c.MinGames || (c.MaxDeviation > 0 && e.Estimate.Deviation > c.MaxDeviation) {
+			continue
+		}
+		standings = append(standings, Standing{Entry: e, Score: c.Key(e)})
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Score != standings[j].Score {
+			return standings[i].Score > standings[j].Score
+		}
+		return standings[i].ID < standings[j].ID
+	})
+
+	n := float64(len(standings))
+	for i := 0; i < len(standings); {
+		j := i
+		for j < len(standings) && standings[j].Score == standings[i].Score {
+			j++
+		}
+		below := n - float64(j)
+		for k := i; k < j; k++ {
+			standings[k].Rank = i + 1
+			standings[k].Percentile = 100 * (below + float64(j-i)/2) / n
+		}
+		i = j
+	}
+
+	ranks := make(map[string]int, len(previous))
+	for _, s := range previous {
+		ranks[s.ID] = s.Rank
+	}
+	for i := range standings {
+		rank, ok := ranks[standings[i].ID]
+		standings[i].New = !ok
+		if ok {
+			standings[i].Movement = rank - standings[i].Rank
+		}
+	}
+	return standings
+}
+
+// Find returns the Standing of the player with the given ID, and whether they are ranked.
+func (s Standings) Find(id string) (Standing, bool) {
+	for _, standing := range s {
+		if standing.ID == id {
+			return standing, true
+		}
+	}
+	return Standing{}, false
+}
+
+func (c Config) withDefaults() Config {
+	if c.Key == nil {
+		c.Key = ByRating
+	}
+	return c
+}
@@ -0,0 +1,114 @@
+package leaderboard
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+)
+
+func entry(id string, r, d float64, games int) Entry {
+	return Entry{ID: id, Estimate: rating.Estimate{Rating: r, Deviation: d}, Games: games}
+}
+
+func TestRank(t *testing.T) {
+	entries := []Entry{
+		entry("d", 1400, 50, 10),
+		entry("b", 1600, 50, 10),
+		entry("c", 1600, 50, 10),
+		entry("a", 1700, 50, 10),
+	}
+	s := Config{}.Rank(entries, nil)
+	ids := []string{"a", "b", "c", "d"}
+	ranks := []int{1, 2, 2, 4}
+	percentiles := []float64{87.5, 50, 50, 12.5}
+	for i := range s {
+		if s[i].ID != ids[i] || s[i].Rank != ranks[i] || math.Abs(s[i].Percentile-percentiles[i]) > 0.000001 || !s[i].New || s[i].Movement != 0 {
+			t.Log(i, s[i])
+			t.Fail()
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	entries := []Entry{
+		entry("steady", 1650, 40, 100),
+		entry("lucky", 1700, 150, 3),
+	}
+	if s := (Config{}).Rank(entries, nil); s[0].ID != "lucky" {
+		t.Log(s)
+		t.Fail()
+	}
+	s := Config{Key: Conservative(2)}.Rank(entries, nil)
+	if s[0].ID != "steady" || s[0].Score != 1570 || s[1].Score != 1400 {
+		t.Log(s)
+		t.Fail()
+	}
+}
+
+func TestThresholds(t *testing.T) {
+	entries := []Entry{
+		entry("a", 1700, 50, 3),
+		entry("b", 1600, 200, 30),
+		entry("c", 1500, 50, 30),
+		entry("elo", 1400, 0, 30),
+	}
+	s := Config{MinGames: 10, MaxDeviation: 100}.Rank(entries, nil)
+	if len(s) != 2 || s[0].ID != "c" || s[1].ID != "elo" {
+		t.Log(s)
+		t.Fail()
+	}
+	if _, ok := s.Find("a"); ok {
+		t.Fail()
+	}
+	if e, ok := s.Find("elo"); !ok || e.Rank != 2 {
+		t.Log(e)
+		t.Fail()
+	}
+}
+
+func TestMovement(t *testing.T) {
+	c := Config{}
+	previous := c.Rank([]Entry{
+		entry("a", 1700, 0, 1),
+		entry("b", 1600, 0, 1),
+		entry("c", 1500, 0, 1),
+	}, nil)
+	current := c.Rank([]Entry{
+		entry("a", 1550, 0, 2),
+		entry("b", 1620, 0, 2),
+		entry("c", 1510, 0, 2),
+		entry("d", 1800, 0, 2),
+	}, previous)
+	movements := map[string]int{"d": 0, "b": 0, "a": -2, "c": -1}
+	for _, s := range current {
+		if s.Movement != movements[s.ID] || s.New != (s.ID == "d") {
+			t.Log(s)
+			t.Fail()
+		}
+	}
+}
+
+func TestSystems(t *testing.T) {
+	e := elo.NewPlayer(elo.Parameters{InitialRating: 1500})
+	e.Win(1500)
+	g := glicko.NewPlayer(glicko.Parameters{InitialRating: 1500, InitialDeviation: 350})
+	g2 := glicko2.NewPlayer(glicko2.Parameters{InitialRating: 1500, InitialDeviation: 350, InitialVolatility: 0.06})
+	entries := []Entry{
+		FromElo("elo", e),
+		FromGlicko("glicko", g, 5),
+		FromGlicko2("glicko2", g2, 5),
+		FromPlayer("adapter", elo.Adapter{Player: e}, 1),
+	}
+	if entries[0].Games != 1 || entries[0].Estimate.Rating != e.Rating || entries[1].Estimate.Deviation != 350 || entries[2].Estimate.Volatility != 0.06 || entries[3].Estimate != entries[0].Estimate {
+		t.Log(entries)
+		t.Fail()
+	}
+	if s := (Config{MaxDeviation: 300}).Rank(entries, nil); len(s) != 2 {
+		t.Log(s)
+		t.Fail()
+	}
+}
@@ -0,0 +1,140 @@
+package openskill
+
+import (
+	"math"
+
+	"github.com/dylrich/rating/internal/normal"
+)
+
+// model returns the update to the rating (Ω) and the variance reduction factor (Δ) of each team, using the algorithms from Weng and Lin's "A Bayesian Approximation Method for Online Ranking". The teams must be sorted by rank.
+func (c Config) model(teams []team) ([]float64, []float64) {
+	switch c.Model {
+	case BradleyTerryFull:
+		return c.pairwise(teams, all(len(teams)), c.bradleyTerry)
+	case BradleyTerryPart:
+		return c.pairwise(teams, neighbours(len(teams)), c.bradleyTerry)
+	case ThurstoneMostellerFull:
+		return c.pairwise(teams, all(len(teams)), c.thurstoneMosteller)
+	case ThurstoneMostellerPart:
+		return c.pairwise(teams, neighbours(len(teams)), c.thurstoneMosteller)
+	default:
+		return c.plackettLuce(teams)
+	}
+}
+
+func (c Config) plackettLuce(teams []team) ([]float64, []float64) {
+	total := 0.0
+	for _, t := range teams {
+		total += t.variance + math.Pow(c.Beta, 2)
+	}
+	ci := math.Sqrt(total)
+
+	// sums holds, for each team q, the total strength of the teams that finished level with or behind it, and tied counts the teams that share its rank.
+	sums := make([]float64, len(teams))
+	tied := make([]float64, len(teams))
+	for q, tq := range teams {
+		for _, t := range teams {
+			if t.rank >= tq.rank {
+				sums[q] += math.Exp(t.rating / ci)
+			}
+			if t.rank == tq.rank {
+				tied[q]++
+			}
+		}
+	}
+
+	omegas := make([]float64, len(teams))
+	deltas := make([]float64, len(teams))
+	for i, ti := range teams {
+		strength := math.Exp(ti.rating / ci)
+		omega, delta := 0.0, 0.0
+		for q, tq := range teams {
+			if tq.rank > ti.rank {
+				continue
+			}
+			p := strength / sums[q]
+			delta += p * (1 - p) / tied[q]
+			if q == i {
+				omega += (1 - p) / tied[q]
+			} else {
+				omega -= p / tied[q]
+			}
+		}
+		gamma := math.Sqrt(ti.variance) / ci
+		omegas[i] = omega * ti.variance / ci
+		deltas[i] = gamma * delta * ti.variance / math.Pow(ci, 2)
+	}
+	return omegas, deltas
+}
+
+// pairwise sums the contributions of comparing each team with its opponents. Opponents lists the indices each team is compared with.
+func (c Config) pairwise(teams []team, opponents [][]int, compare func(ti, tq team, ciq float64) (float64, float64)) ([]float64, []float64) {
+	omegas := make([]float64, len(teams))
+	deltas := make([]float64, len(teams))
+	for i, ti := range teams {
+		for _, q := range opponents[i] {
+			tq := teams[q]
+			ciq := math.Sqrt(ti.variance + tq.variance + 2*math.Pow(c.Beta, 2))
+			omega, delta := compare(ti, tq, ciq)
+			gamma := math.Sqrt(ti.variance) / ciq
+			omegas[i] += ti.variance / ciq * omega
+			deltas[i] += gamma * ti.variance / math.Pow(ciq, 2) * delta
+		}
+	}
+	return omegas, deltas
+}
+
+func (c Config) bradleyTerry(ti, tq team, ciq float64) (float64, float64) {
+	p := 1 / (1 + math.Exp((tq.rating-ti.rating)/ciq))
+	return score(ti, tq) - p, p * (1 - p)
+}
+
+func (c Config) thurstoneMosteller(ti, tq team, ciq float64) (float64, float64) {
+	x := (ti.rating - tq.rating) / ciq
+	t := c.Epsilon / ciq
+	switch {
+	case ti.rank == tq.rank:
+		return normal.VDraw(x, t), normal.WDraw(x, t)
+	case ti.rank < tq.rank:
+		return normal.VWin(x, t), normal.WWin(x, t)
+	default:
+		return -normal.VWin(-x, t), normal.WWin(-x, t)
+	}
+}
+
+// score is 1 if team ti finished ahead of team tq, 0.5 if they drew, and 0 otherwise.
+func score(ti, tq team) float64 {
+	switch {
+	case ti.rank < tq.rank:
+		return 1
+	case ti.rank == tq.rank:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+func all(n int) [][]int {
+	opponents := make([][]int, n)
+	for i := range opponents {
+		for q := 0; q < n; q++ {
+			if q != i {
+				opponents[i] = append(opponents[i], q)
+			}
+		}
+	}
+	return opponents
+}
+
+func neighbours(n int) [][]int {
+	opponents := make([][]int, n)
+	for i := range opponents {
+		if i > 0 {
+			opponents[i] = append(opponents[i], i-1)
+		}
+		if i < n-1 {
+			opponents[i] = append(opponents[i], i+1)
+		}
+	}
+	return opponents
+}
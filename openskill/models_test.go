@@ -0,0 +1,65 @@
+package openskill
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBradleyTerry(t *testing.T) {
+	c := Config{Model: BradleyTerryFull}
+	check(t, "full 2 players", rated(t, newTeams(c, 1, 1), nil), []expectation{{27.635231, 8.065506}, {22.364769, 8.065506}})
+	check(t, "full 4 players", rated(t, newTeams(c, 1, 1, 1, 1), nil), []expectation{
+		{32.905694, 7.501219},
+		{27.635231, 7.501219},
+		{22.364769, 7.501219},
+		{17.094306, 7.501219},
+	})
+	check(t, "full draw", rated(t, newTeams(c, 1, 1, 1), []int{1, 1, 2}), []expectation{{27.635231, 7.788475}, {27.635231, 7.788475}, {19.729537, 7.788475}})
+
+	c.Model = BradleyTerryPart
+	check(t, "part 4 players", rated(t, newTeams(c, 1, 1, 1, 1), nil), []expectation{
+		{27.635231, 8.065506},
+		{25, 7.788475},
+		{25, 7.788475},
+		{22.364769, 8.065506},
+	})
+	check(t, "part ranked", rated(t, newTeams(c, 1, 1, 1), []int{2, 1, 3}), []expectation{{25, 7.788475}, {27.635231, 8.065506}, {22.364769, 8.065506}})
+}
+
+func TestThurstoneMosteller(t *testing.T) {
+	c := Config{Model: ThurstoneMostellerFull}
+
+	// For two evenly matched players, the winner's rating moves by σ²/c·φ(ε/c)/Φ(-ε/c), where c² = 2σ² + 2β².
+	ci := math.Sqrt(2*math.Pow(DefaultInitialDeviation, 2) + 2*math.Pow(DefaultBeta, 2))
+	x := -DefaultEpsilon / ci
+	v := math.Exp(-x*x/2) / math.Sqrt(2*math.Pi) / (math.Erfc(-x/math.Sqrt2) / 2)
+	win := math.Pow(DefaultInitialDeviation, 2) / ci * v
+	check(t, "full 2 players", rated(t, newTeams(c, 1, 1), nil), []expectation{{25 + win, 7.630935}, {25 - win, 7.630935}})
+	check(t, "full 4 players", rated(t, newTeams(c, 1, 1, 1, 1), nil), []expectation{
+		{37.692156, 5.983695},
+		{29.230719, 5.983695},
+		{20.769281, 5.983695},
+		{12.307844, 5.983695},
+	})
+	check(t, "full draw", rated(t, newTeams(c, 1, 1), []int{1, 1}), []expectation{{25, 7.202539}, {25, 7.202539}})
+
+	c.Model = ThurstoneMostellerPart
+	check(t, "part 4 players", rated(t, newTeams(c, 1, 1, 1, 1), nil), []expectation{
+		{25 + win, 7.630935},
+		{25, 6.856959},
+		{25, 6.856959},
+		{25 - win, 7.630935},
+	})
+	check(t, "part draw", rated(t, newTeams(c, 1, 1, 1), []int{2, 1, 2}), []expectation{{25 - win, 6.376778}, {25 + win, 7.630935}, {25, 7.202539}})
+}
+
+func TestUnevenTeams(t *testing.T) {
+	for m := PlackettLuce; m <= ThurstoneMostellerPart; m++ {
+		c := Config{Model: m}
+		outcomes := rated(t, newTeams(c, 1, 3), []int{2, 1})
+		if outcomes[0][0].RatingDelta >= 0 || outcomes[1][2].RatingDelta <= 0 || outcomes[0][0].DeviationDelta >= 0 {
+			t.Log(m, outcomes)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package openskill
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+const (
+
+	// DefaultInitialDeviation is the standard value for an initial deviation (σ) for players that have no result history.
+	DefaultInitialDeviation = DefaultInitialRating / 3
+
+	// DefaultInitialRating is the standard value for an initial rating (μ) for players that have no result history.
+	DefaultInitialRating = 25.0
+
+	// DefaultBeta is the standard value for Config.Beta.
+	DefaultBeta = DefaultInitialDeviation / 2
+
+	// DefaultKappa is the standard value for Config.Kappa.
+	DefaultKappa = 0.0001
+
+	// DefaultEpsilon is the standard value for Config.Epsilon.
+	DefaultEpsilon = 0.1
+)
+
+// Model selects which of the Weng-Lin Bayesian approximation models is used to rate matches.
+type Model int
+
+const (
+
+	// PlackettLuce models a match as the teams being drawn one at a time, best first, with each remaining team chosen with probability proportional to its strength. It is the default model and scales well to matches between many teams.
+	PlackettLuce Model = iota
+
+	// BradleyTerryFull compares every pair of teams in the match with a logistic model.
+	BradleyTerryFull
+
+	// BradleyTerryPart compares each team with the teams that finished immediately above and below it with a logistic model.
+	BradleyTerryPart
+
+	// ThurstoneMostellerFull compares every pair of teams in the match with a normal model.
+	ThurstoneMostellerFull
+
+	// ThurstoneMostellerPart compares each team with the teams that finished immediately above and below it with a normal model.
+	ThurstoneMostellerPart
+)
+
+// Config contains the system-wide settings for a league. It is passed to each Player on construction, so leagues with different settings can be rated side by side in the same process. Any zero field is replaced with its package default when the Config is used to create a Player, except for Model, whose zero value is PlackettLuce, and Tau, which is only applied when set.
+type Config struct {
+
+	// Model is the Weng-Lin model used to rate matches.
+	Model Model
+
+	// Beta (β) is the deviation of a player's performance in a single match around their true skill.
+	Beta float64
+
+	// Kappa (κ) is the smallest factor by which a single match may shrink a player's variance, which keeps deviations from collapsing to zero.
+	Kappa float64
+
+	// Epsilon (ε) is the draw margin used by the Thurstone-Mosteller models.
+	Epsilon float64
+
+	// Tau (τ) is added to every player's deviation before each match, so that ratings can keep changing as skill changes over time.
+	Tau float64
+}
+
+// Player represents an individual participant in the competition. The Player struct contains the Rating (μ) and Deviation (σ) measures which compose the system's estimation of how skilled that player is as well as how reliable that estimation is. These values are moment-in-time snapshots, and will be updated on any new results for that player. The Parameters attribute contains initial values for that player, and should be altered at the beginning of a new rating period with NewPeriod.
+type Player struct {
+	Rating     float64
+	Deviation  float64
+	History    []Result
+	Parameters Parameters
+	Config     Config
+}
+
+// Parameters contains initial values for a player. These are set on instantiation of the player, and can be altered later by using the Player.NewPeriod() method.
+type Parameters struct {
+	InitialDeviation, InitialRating float64
+}
+
+// Result contains the important information from a match that has occurred: the number of teams that took part, and how many of them finished ahead of and level with the player's team.
+type Result struct {
+	Teams, Ahead, Tied int
+}
+
+// Outcome is a snapshot of the current state for a player, including delta values for each Deviation and Rating change. This information can be passed to users to give them an idea of how much the most recent result has impacted their ranking criteria.
+type Outcome struct {
+	Rating, RatingDelta, Deviation, DeviationDelta float64
+}
+
+// Team is a group of players competing together in a match. A team's strength is the sum of its players' ratings.
+type Team struct {
+	Players []*Player
+}
+
+// DefaultConfig returns a Config populated with the package default values.
+func DefaultConfig() Config {
+	return Config{
+		Model:   PlackettLuce,
+		Beta:    DefaultBeta,
+		Kappa:   DefaultKappa,
+		Epsilon: DefaultEpsilon,
+	}
+}
+
+// NewPlayer is used to instantiate a new Player object based on the input parameters, using DefaultConfig for the system settings.
+func NewPlayer(p Parameters) *Player {
+	return DefaultConfig().NewPlayer(p)
+}
+
+// NewPlayer is used to instantiate a new Player object that is rated using the calling Config. A zero InitialRating or InitialDeviation is replaced with the package default.
+func (c Config) NewPlayer(p Parameters) *Player {
+	if p.InitialDeviation == 0 {
+		p.InitialDeviation = DefaultInitialDeviation
+	}
+	if p.InitialRating == 0 {
+		p.InitialRating = DefaultInitialRating
+	}
+	return &Player{Rating: p.InitialRating, Deviation: p.InitialDeviation, Parameters: p, Config: c.withDefaults()}
+}
+
+// Win is called when a player has won a match against another player, described by their rating and deviation. This function will handle updating the calling Player only. To add the loss to the opponent's rating, call Opponent.Lose(Player) as appropriate.
+func (p *Player) Win(rating, deviation float64) Outcome {
+	return p.headToHead(rating, deviation, []int{1, 2})
+}
+
+// Lose is called when a player has lost a match against another player, described by their rating and deviation. This function will handle updating the calling Player only. To add the win to the opponent's rating, call Opponent.Win(Player) as appropriate.
+func (p *Player) Lose(rating, deviation float64) Outcome {
+	return p.headToHead(rating, deviation, []int{2, 1})
+}
+
+// Draw is called when a player has tied in a match against another player, described by their rating and deviation. This function will handle updating the calling Player only. To add the draw to the opponent's rating, call Opponent.Draw(Player) as appropriate.
+func (p *Player) Draw(rating, deviation float64) Outcome {
+	return p.headToHead(rating, deviation, []int{1, 1})
+}
+
+// Reset will wipe the calling Player's history completely, and revert the current Rating and Deviation to the initial values.
+func (p *Player) Reset() {
+	p.History = []Result{}
+	p.Deviation = p.Parameters.InitialDeviation
+	p.Rating = p.Parameters.InitialRating
+}
+
+// NewPeriod takes the calling Player's current Rating and Deviation, and sets them as the new initital values before resetting the player's history to empty.
+func (p *Player) NewPeriod() {
+	p.Parameters.InitialDeviation = p.Deviation
+	p.Parameters.InitialRating = p.Rating
+	p.Reset()
+}
+
+// ErrRanks is returned by Rate when ranks does not have one entry per team.
+var ErrRanks = errors.New("openskill: ranks must have one entry per team")
+
+// Rate rates a match between any number of teams of any size, using the Config.Model of the first player. Ranks gives each team's finishing position, where a lower rank finished ahead of a higher one and teams with equal ranks drew. If ranks is nil, the teams are assumed to have finished in the order given with no draws. Every player's Rating and Deviation is updated and a Result is added to their History. The returned Outcomes are grouped and ordered in the same way as teams. If ranks does not have one entry per team, ErrRanks is returned and no player is changed.
+func Rate(teams []Team, ranks []int) ([][]Outcome, error) {
+	if ranks == nil {
+		ranks = make([]int, len(teams))
+		for i := range ranks {
+			ranks[i] = i
+		}
+	}
+	if len(ranks) != len(teams) {
+		return nil, ErrRanks
+	}
+	ratings := rate(teams, ranks)
+	outcomes := make([][]Outcome, len(teams))
+	for i, t := range teams {
+		ahead, tied := 0, -1
+		for _, r := range ranks {
+			if r < ranks[i] {
+				ahead++
+			} else if r == ranks[i] {
+				tied++
+			}
+		}
+		outcomes[i] = make([]Outcome, len(t.Players))
+		for j, p := range t.Players {
+			outcomes[i][j] = p.apply(ratings[i][j])
+			p.History = append(p.History, Result{Teams: len(teams), Ahead: ahead, Tied: tied})
+		}
+	}
+	return outcomes, nil
+}
+
+func (p *Player) headToHead(rating, deviation float64, ranks []int) Outcome {
+	opponent := p.Config.NewPlayer(Parameters{InitialRating: rating, InitialDeviation: deviation})
+	teams := []Team{{Players: []*Player{p}}, {Players: []*Player{opponent}}}
+	outcome := p.apply(rate(teams, ranks)[0][0])
+	tied := 0
+	if ranks[0] == ranks[1] {
+		tied = 1
+	}
+	ahead := 0
+	if ranks[1] < ranks[0] {
+		ahead = 1
+	}
+	p.History = append(p.History, Result{Teams: 2, Ahead: ahead, Tied: tied})
+	return outcome
+}
+
+func (p *Player) apply(e estimate) Outcome {
+	outcome := Outcome{
+		Rating:         e.rating,
+		RatingDelta:    e.rating - p.Rating,
+		Deviation:      e.deviation,
+		DeviationDelta: e.deviation - p.Deviation,
+	}
+	p.Rating = e.rating
+	p.Deviation = e.deviation
+	return outcome
+}
+
+func (c Config) withDefaults() Config {
+	if c.Beta == 0 {
+		c.Beta = DefaultBeta
+	}
+	if c.Kappa == 0 {
+		c.Kappa = DefaultKappa
+	}
+	if c.Epsilon == 0 {
+		c.Epsilon = DefaultEpsilon
+	}
+	return c
+}
+
+type estimate struct {
+	rating, deviation float64
+}
+
+// team is the combined rating of a team within a match. Variance is the sum of the variances of its players.
+type team struct {
+	rating, variance float64
+	rank             int
+	players          []estimate
+}
+
+// rate computes each player's new rating after a match, grouped and ordered in the same way as teams. The teams are sorted by rank before the model is applied, so that the partial models can compare neighbouring finishers.
+func rate(teams []Team, ranks []int) [][]estimate {
+	posteriors := make([][]estimate, len(teams))
+	if len(teams) < 2 {
+		for i, t := range teams {
+			posteriors[i] = make([]estimate, len(t.Players))
+			for j, p := range t.Players {
+				posteriors[i][j] = estimate{p.Rating, p.Deviation}
+			}
+		}
+		return posteriors
+	}
+	c := teams[0].Players[0].Config
+	order := make([]int, len(teams))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return ranks[order[a]] < ranks[order[b]]
+	})
+	sorted := make([]team, len(teams))
+	for i, index := range order {
+		t := team{rank: ranks[index]}
+		for _, p := range teams[index].Players {
+			deviation := math.Sqrt(math.Pow(p.Deviation, 2) + math.Pow(c.Tau, 2))
+			t.players = append(t.players, estimate{p.Rating, deviation})
+			t.rating += p.Rating
+			t.variance += math.Pow(deviation, 2)
+		}
+		sorted[i] = t
+	}
+	omegas, deltas := c.model(sorted)
+	for i, index := range order {
+		t := sorted[i]
+		posteriors[index] = make([]estimate, len(t.players))
+		for j, p := range t.players {
+			share := math.Pow(p.deviation, 2) / t.variance
+			posteriors[index][j] = estimate{
+				rating:    p.rating + share*omegas[i],
+				deviation: p.deviation * math.Sqrt(math.Max(1-share*deltas[i], c.Kappa)),
+			}
+		}
+	}
+	return posteriors
+}
@@ -0,0 +1,157 @@
+package openskill
+
+import (
+	"math"
+	"testing"
+)
+
+type expectation struct {
+	rating, deviation float64
+}
+
+func newTeams(c Config, sizes ...int) []Team {
+	teams := make([]Team, len(sizes))
+	for i, size := range sizes {
+		for j := 0; j < size; j++ {
+			teams[i].Players = append(teams[i].Players, c.NewPlayer(Parameters{}))
+		}
+	}
+	return teams
+}
+
+func check(t *testing.T, name string, outcomes [][]Outcome, expected []expectation) {
+	t.Helper()
+	i := 0
+	for _, team := range outcomes {
+		for _, o := range team {
+			if math.Abs(o.Rating-expected[i].rating) > .000001 || math.Abs(o.Deviation-expected[i].deviation) > .000001 {
+				t.Log(name, i, o, expected[i])
+				t.Fail()
+			}
+			i++
+		}
+	}
+	if i != len(expected) {
+		t.Log(name, outcomes)
+		t.Fail()
+	}
+}
+
+// rated calls Rate and fails the test if it returns an error.
+func rated(t *testing.T, teams []Team, ranks []int) [][]Outcome {
+	t.Helper()
+	outcomes, err := Rate(teams, ranks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return outcomes
+}
+
+// The expected values for four players are the reference values published with openskill.js.
+func TestPlackettLuce(t *testing.T) {
+	c := DefaultConfig()
+	check(t, "2 players", rated(t, newTeams(c, 1, 1), nil), []expectation{{27.635231, 8.065506}, {22.364769, 8.065506}})
+	check(t, "4 players", rated(t, newTeams(c, 1, 1, 1, 1), nil), []expectation{
+		{27.795085, 8.263161},
+		{26.552825, 8.179214},
+		{24.689435, 8.083731},
+		{20.962655, 8.083731},
+	})
+	check(t, "4 players ranked", rated(t, newTeams(c, 1, 1, 1, 1), []int{4, 1, 3, 2}), []expectation{
+		{20.962655, 8.083731},
+		{27.795085, 8.263161},
+		{24.689435, 8.083731},
+		{26.552825, 8.179214},
+	})
+	check(t, "3 players tied", rated(t, newTeams(c, 1, 1, 1), []int{1, 1, 2}), []expectation{{25.717219, 8.204837}, {25.717219, 8.204837}, {23.565562, 8.204837}})
+}
+
+func TestTeams(t *testing.T) {
+	teams := newTeams(DefaultConfig(), 1, 2)
+	teams[1].Players[0].Deviation = 4
+	outcomes := rated(t, teams, nil)
+	if outcomes[0][0].RatingDelta <= 0 || outcomes[1][0].RatingDelta >= 0 || outcomes[1][1].RatingDelta >= 0 {
+		t.Log(outcomes)
+		t.Fail()
+	}
+
+	// Each player's share of the team's update is proportional to their variance.
+	share := outcomes[1][0].RatingDelta / outcomes[1][1].RatingDelta
+	if math.Abs(share-16/math.Pow(DefaultInitialDeviation, 2)) > .000001 {
+		t.Log(share)
+		t.Fail()
+	}
+	if teams[1].Players[1].History[0].Ahead != 1 || teams[1].Players[1].History[0].Teams != 2 {
+		t.Log(teams[1].Players[1].History)
+		t.Fail()
+	}
+
+	teams = newTeams(DefaultConfig(), 1, 1)
+	if _, err := Rate(teams, []int{1}); err != ErrRanks || len(teams[0].Players[0].History) != 0 {
+		t.Log(err, teams[0].Players[0])
+		t.Fail()
+	}
+}
+
+func TestHeadToHead(t *testing.T) {
+	p := NewPlayer(Parameters{})
+	if o := p.Win(DefaultInitialRating, DefaultInitialDeviation); math.Abs(o.Rating-27.635231) > .000001 || math.Abs(o.Deviation-8.065506) > .000001 {
+		t.Log(o)
+		t.Fail()
+	}
+	if r := p.History[0]; r.Teams != 2 || r.Ahead != 0 || r.Tied != 0 {
+		t.Log(p.History)
+		t.Fail()
+	}
+
+	p = NewPlayer(Parameters{})
+	if o := p.Lose(DefaultInitialRating, DefaultInitialDeviation); math.Abs(o.Rating-22.364769) > .000001 || p.History[0].Ahead != 1 {
+		t.Log(o, p.History)
+		t.Fail()
+	}
+
+	p = NewPlayer(Parameters{})
+	if o := p.Draw(DefaultInitialRating, DefaultInitialDeviation); o.RatingDelta != 0 || o.DeviationDelta >= 0 || p.History[0].Tied != 1 {
+		t.Log(o, p.History)
+		t.Fail()
+	}
+}
+
+func TestTau(t *testing.T) {
+	c := Config{Tau: DefaultInitialDeviation / 100}
+	teams := newTeams(c, 1, 1)
+	for i := 0; i < 100; i++ {
+		rated(t, teams, []int{1, 1})
+	}
+	if d := teams[0].Players[0].Deviation; d < 1 {
+		t.Log(d)
+		t.Fail()
+	}
+}
+
+func TestKappa(t *testing.T) {
+	c := Config{Kappa: 0.5}
+	outcomes := rated(t, newTeams(c, 1, 1, 1, 1, 1, 1, 1, 1), nil)
+	for _, o := range outcomes {
+		if o[0].Deviation < DefaultInitialDeviation*math.Sqrt(0.5)-.000001 {
+			t.Log(o)
+			t.Fail()
+		}
+	}
+}
+
+func TestNewPeriod(t *testing.T) {
+	p := NewPlayer(Parameters{})
+	p.Win(DefaultInitialRating, DefaultInitialDeviation)
+	p.NewPeriod()
+	if len(p.History) != 0 || math.Abs(p.Parameters.InitialRating-27.635231) > .000001 {
+		t.Log(p)
+		t.Fail()
+	}
+	p.Win(DefaultInitialRating, DefaultInitialDeviation)
+	p.Reset()
+	if len(p.History) != 0 || p.Rating != p.Parameters.InitialRating || p.Deviation != p.Parameters.InitialDeviation {
+		t.Log(p)
+		t.Fail()
+	}
+}
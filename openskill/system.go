@@ -0,0 +1,65 @@
+package openskill
+
+import (
+	"github.com/dylrich/rating"
+)
+
+// System implements rating.System for the Weng-Lin models. Every Player it creates starts from the same Parameters and is rated using the same Config.
+type System struct {
+	Parameters Parameters
+	Config     Config
+}
+
+// Adapter wraps a Player so that it satisfies rating.Player. The wrapped Player is updated in place, so it can still be used directly alongside the adapter.
+type Adapter struct {
+	Player *Player
+}
+
+// Name returns the name of the rating system.
+func (s System) Name() string {
+	return "openskill"
+}
+
+// NewPlayer returns a new Player wrapped in an Adapter.
+func (s System) NewPlayer() rating.Player {
+	return Adapter{Player: s.Config.NewPlayer(s.Parameters)}
+}
+
+// Estimate returns the wrapped Player's current Rating and Deviation.
+func (a Adapter) Estimate() rating.Estimate {
+	return rating.Estimate{Rating: a.Player.Rating, Deviation: a.Player.Deviation}
+}
+
+// Win records a win against the opponent. The opponent's Volatility is ignored.
+func (a Adapter) Win(opponent rating.Estimate) rating.Outcome {
+	return a.Player.Win(opponent.Rating, opponent.Deviation).generic()
+}
+
+// Lose records a loss against the opponent. The opponent's Volatility is ignored.
+func (a Adapter) Lose(opponent rating.Estimate) rating.Outcome {
+	return a.Player.Lose(opponent.Rating, opponent.Deviation).generic()
+}
+
+// Draw records a draw against the opponent. The opponent's Volatility is ignored.
+func (a Adapter) Draw(opponent rating.Estimate) rating.Outcome {
+	return a.Player.Draw(opponent.Rating, opponent.Deviation).generic()
+}
+
+// Reset calls Reset on the wrapped Player.
+func (a Adapter) Reset() {
+	a.Player.Reset()
+}
+
+// NewPeriod calls NewPeriod on the wrapped Player.
+func (a Adapter) NewPeriod() {
+	a.Player.NewPeriod()
+}
+
+func (o Outcome) generic() rating.Outcome {
+	return rating.Outcome{
+		Rating:         o.Rating,
+		RatingDelta:    o.RatingDelta,
+		Deviation:      o.Deviation,
+		DeviationDelta: o.DeviationDelta,
+	}
+}
@@ -0,0 +1,30 @@
+package openskill
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestSystem(t *testing.T) {
+	var s rating.System = System{}
+	a := s.NewPlayer()
+	outcome := a.Win(rating.Estimate{Rating: DefaultInitialRating, Deviation: DefaultInitialDeviation})
+	if math.Abs(outcome.Rating-27.635) > 0.001 || math.Abs(outcome.Deviation-8.066) > 0.001 {
+		t.Log(outcome)
+		t.Fail()
+	}
+	if a.Estimate() != outcome.Estimate() {
+		t.Log(a.Estimate(), outcome)
+		t.Fail()
+	}
+
+	// The System's Config is used for every player it creates, so the Model changes the result.
+	s = System{Config: Config{Model: ThurstoneMostellerFull}}
+	outcome = s.NewPlayer().Draw(rating.Estimate{Rating: DefaultInitialRating, Deviation: DefaultInitialDeviation})
+	if math.Abs(outcome.Rating-25) > 0.000001 || math.Abs(outcome.Deviation-7.202539) > 0.000001 {
+		t.Log(outcome)
+		t.Fail()
+	}
+}
@@ -0,0 +1,111 @@
+// Package rating defines the system-agnostic types shared by the elo, glicko, glicko2, trueskill, and openskill packages. Each of those packages provides a System and a Player adapter that satisfy the interfaces below, which allows an application to swap rating algorithms behind a single API or to run several systems side by side.
+package rating
+
+import (
+	"math"
+)
+
+// Estimate is a moment-in-time snapshot of a player's skill. Systems that do not track a particular measure leave it at zero, e.g. Elo has no Deviation or Volatility.
+type Estimate struct {
+	Rating, Deviation, Volatility float64
+}
+
+// Outcome is a snapshot of the current state for a player, including delta values for each measure that changed. Systems that do not track a particular measure leave it and its delta at zero.
+type Outcome struct {
+	Rating, RatingDelta, Deviation, DeviationDelta, Volatility, VolatilityDelta float64
+}
+
+// Estimate returns the post-result state described by the Outcome.
+func (o Outcome) Estimate() Estimate {
+	return Estimate{Rating: o.Rating, Deviation: o.Deviation, Volatility: o.Volatility}
+}
+
+// Player is a participant rated by some System. Win, Lose, and Draw update the calling Player only, in the same way as the concrete Player types in each system package. Opponents are described by their Estimate so that callers never need to know which system produced it.
+type Player interface {
+	Estimate() Estimate
+	Win(opponent Estimate) Outcome
+	Lose(opponent Estimate) Outcome
+	Draw(opponent Estimate) Outcome
+	Reset()
+	NewPeriod()
+}
+
+// System creates new Players for a particular rating algorithm. Name identifies the algorithm, which is useful when several systems are run side by side.
+type System interface {
+	Name() string
+	NewPlayer() Player
+}
+
+// Game is a historical result between two players identified by ID. Chronological lists of games are used to calibrate and evaluate rating systems. Period is the index of the rating period the game was played in, and Score is the result for Player: 1 for a win, 0 for a loss, and 0.5 for a draw. Advantage is the share of the league's home or first-move advantage held by Player: 1 when Player holds it, -1 when Opponent holds it, and 0 at a neutral venue.
+type Game struct {
+	Period           int
+	Player, Opponent string
+	Score, Advantage float64
+}
+
+// LogLoss returns the logarithmic loss of predicting an expected score of expected when the actual score was score. The expected score is clamped away from 0 and 1 so that the loss is always finite.
+func LogLoss(expected, score float64) float64 {
+	expected = math.Min(math.Max(expected, 1e-15), 1-1e-15)
+	return -(score*math.Log(expected) + (1-score)*math.Log(1-expected))
+}
+
+// BrierScore returns the squared error of predicting an expected score of expected when the actual score was score.
+func BrierScore(expected, score float64) float64 {
+	return math.Pow(expected-score, 2)
+}
+
+// Aggregation selects how the ratings and deviations of a team's members are combined into a single composite rating and deviation for team matches.
+type Aggregation int
+
+const (
+
+	// Mean uses the average of the members' ratings, so team ratings stay on the same scale as individual ratings regardless of team size.
+	Mean Aggregation = iota
+
+	// Sum uses the total of the members' ratings, so larger teams are stronger than smaller teams of equally rated players.
+	Sum
+
+	// Playtime uses the average of the members' ratings weighted by the share of the match each member played.
+	Playtime
+)
+
+// Weights returns the coefficient of each member's rating in the composite team rating. The playtimes are only used by Playtime, where they are normalised to sum to one. If every playtime is zero, Playtime behaves like Mean.
+func (a Aggregation) Weights(playtimes []float64) []float64 {
+	weights := make([]float64, len(playtimes))
+	total := 0.0
+	for _, t := range playtimes {
+		total += t
+	}
+	for i, t := range playtimes {
+		switch {
+		case a == Sum:
+			weights[i] = 1
+		case a == Playtime && total > 0:
+			weights[i] = t / total
+		default:
+			weights[i] = 1 / float64(len(playtimes))
+		}
+	}
+	return weights
+}
+
+// Probabilities holds the separate chances of a win, a draw, and a loss for a player in a match, as predicted by a draw model. The three values sum to one.
+type Probabilities struct {
+	Win, Draw, Loss float64
+}
+
+// Expected returns the expected score implied by the Probabilities, counting a draw as half a win.
+func (p Probabilities) Expected() float64 {
+	return p.Win + p.Draw/2
+}
+
+// LogLoss returns the logarithmic loss of the Probabilities when the actual score was score, which must be 1 for a win, 0.5 for a draw, or 0 for a loss. Unlike the LogLoss of an expected score, it rewards a model for predicting draws as well as the balance between the two players. The predicted probability of the result is clamped away from 0 so that the loss is always finite.
+func (p Probabilities) LogLoss(score float64) float64 {
+	probability := p.Draw
+	if score == 1 {
+		probability = p.Win
+	} else if score == 0 {
+		probability = p.Loss
+	}
+	return -math.Log(math.Max(probability, 1e-15))
+}
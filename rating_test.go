@@ -0,0 +1,21 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProbabilities(t *testing.T) {
+	p := Probabilities{Win: 0.5, Draw: 0.3, Loss: 0.2}
+	if math.Abs(p.Expected()-0.65) > 0.000001 {
+		t.Log(p.Expected())
+		t.Fail()
+	}
+	if math.Abs(p.LogLoss(1)+math.Log(0.5)) > 0.000001 || math.Abs(p.LogLoss(0.5)+math.Log(0.3)) > 0.000001 || math.Abs(p.LogLoss(0)+math.Log(0.2)) > 0.000001 {
+		t.Log(p.LogLoss(1), p.LogLoss(0.5), p.LogLoss(0))
+		t.Fail()
+	}
+	if math.IsInf((Probabilities{Win: 1}).LogLoss(0), 0) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,86 @@
+package replay
+
+import (
+	"errors"
+
+	"github.com/dylrich/rating"
+)
+
+// ErrMatchNotFound is returned when the match to be voided or amended is not in the history.
+var ErrMatchNotFound = errors.New("replay: match not found")
+
+// Void replays the history without the match with the given ID, for example because a referee has overturned it, and returns the new Ratings along with the change in the final state of every player that the removal affected, keyed by player ID. Because every later rating is replayed, the change reaches beyond the two players of the match to everyone whose ratings depended on them. The players of the voided match always appear in the new Ratings, even if it was their only match. The rating periods are laid out as they were before the match was removed.
+func (c Config) Void(matches []Match, id string) (Ratings, map[string]rating.Outcome, error) {
+	return c.correct(matches, id, func(m Match) (Match, bool) {
+		return m, false
+	})
+}
+
+// Amend replays the history with the Score of the match with the given ID replaced, and returns the new Ratings along with the change in the final state of every player that the amendment affected, as described for Void. The score is the result for the match's Player, and the Opponent receives the complementary score.
+func (c Config) Amend(matches []Match, id string, score float64) (Ratings, map[string]rating.Outcome, error) {
+	return c.correct(matches, id, func(m Match) (Match, bool) {
+		m.Score = score
+		return m, true
+	})
+}
+
+func (c Config) correct(matches []Match, id string, change func(m Match) (Match, bool)) (Ratings, map[string]rating.Outcome, error) {
+	before, err := c.Replay(matches)
+	if err != nil {
+		return Ratings{}, nil, err
+	}
+	if c.Start.IsZero() && len(before.Periods) > 0 && c.Period > 0 {
+		c.Start = before.Periods[0].Start
+	}
+	corrected := make([]Match, 0, len(matches))
+	var players []string
+	for _, m := range matches {
+		if m.ID != id || id == "" {
+			corrected = append(corrected, m)
+			continue
+		}
+		players = append(players, m.Player, m.Opponent)
+		if m, keep := change(m); keep {
+			corrected = append(corrected, m)
+		}
+	}
+	if players == nil {
+		return Ratings{}, nil, ErrMatchNotFound
+	}
+	after, err := c.replay(corrected, players)
+	if err != nil {
+		return Ratings{}, nil, err
+	}
+	old := before.estimates()
+	outcomes := make(map[string]rating.Outcome)
+	for player, e := range after.estimates() {
+		o := old[player]
+		if e == o {
+			continue
+		}
+		outcomes[player] = rating.Outcome{
+			Rating:          e.Rating,
+			RatingDelta:     e.Rating - o.Rating,
+			Deviation:       e.Deviation,
+			DeviationDelta:  e.Deviation - o.Deviation,
+			Volatility:      e.Volatility,
+			VolatilityDelta: e.Volatility - o.Volatility,
+		}
+	}
+	return after, outcomes, nil
+}
+
+// estimates returns the final Estimate of every player in the Ratings.
+func (r Ratings) estimates() map[string]rating.Estimate {
+	estimates := make(map[string]rating.Estimate)
+	for id := range r.Elo {
+		estimates[id], _ = r.Estimate(id)
+	}
+	for id := range r.Glicko {
+		estimates[id], _ = r.Estimate(id)
+	}
+	for id := range r.Glicko2 {
+		estimates[id], _ = r.Estimate(id)
+	}
+	return estimates
+}
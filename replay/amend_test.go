@@ -0,0 +1,82 @@
+package replay
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dylrich/rating/glicko"
+)
+
+func TestVoid(t *testing.T) {
+	c := Config{System: glicko.System{Parameters: glicko.Parameters{InitialRating: 1500, InitialDeviation: 350}, Config: glicko.DefaultConfig()}, Period: 7 * 24 * time.Hour}
+	matches := history()
+
+	// Voiding the very first match must not move the period boundaries.
+	for _, i := range []int{0, 40} {
+		voided := matches[i]
+		r, outcomes, err := c.Void(matches, voided.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		without := append(append([]Match{}, matches[:i]...), matches[i+1:]...)
+		exp, _ := Config{System: c.System, Start: start, Period: c.Period}.Replay(without)
+		for id, p := range exp.Glicko {
+			if r.Glicko[id].Rating != p.Rating || r.Glicko[id].Deviation != p.Deviation {
+				t.Log(i, id, r.Glicko[id], p)
+				t.Fail()
+			}
+		}
+
+		// The change reaches every later opponent, not only the two players of the voided match.
+		if _, ok := outcomes[voided.Player]; !ok || len(outcomes) <= 2 {
+			t.Log(i, outcomes)
+			t.Fail()
+		}
+		for id, o := range outcomes {
+			if o.Rating != r.Glicko[id].Rating {
+				t.Log(i, id, o)
+				t.Fail()
+			}
+		}
+	}
+
+	if _, _, err := c.Void(matches, "missing"); err != ErrMatchNotFound {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestVoidOnlyMatch(t *testing.T) {
+	c := Config{System: glicko.System{Parameters: glicko.Parameters{InitialRating: 1500, InitialDeviation: 350}, Config: glicko.DefaultConfig()}}
+	r, outcomes, err := c.Void([]Match{{ID: "1", Time: start, Player: "a", Opponent: "b", Score: 1}}, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Glicko["a"].Rating != 1500 || r.Glicko["a"].Deviation != 350 || outcomes["a"].RatingDelta >= 0 || outcomes["b"].RatingDelta <= 0 {
+		t.Log(r.Glicko["a"], outcomes)
+		t.Fail()
+	}
+}
+
+func TestAmend(t *testing.T) {
+	c := Config{System: glicko.System{Parameters: glicko.Parameters{InitialRating: 1500, InitialDeviation: 350}, Config: glicko.DefaultConfig()}, Period: 7 * 24 * time.Hour}
+	matches := history()
+	amended := append([]Match{}, matches...)
+	amended[40].Score = 1 - amended[40].Score
+	exp, _ := c.Replay(amended)
+	r, outcomes, err := c.Amend(matches, matches[40].ID, amended[40].Score)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, exp) || len(outcomes) <= 2 {
+		t.Log(outcomes)
+		t.Fail()
+	}
+
+	// Amending a match to its recorded score changes nothing.
+	if _, outcomes, _ := c.Amend(matches, matches[40].ID, matches[40].Score); len(outcomes) != 0 {
+		t.Log(outcomes)
+		t.Fail()
+	}
+}
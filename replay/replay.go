@@ -0,0 +1,154 @@
+// Package replay rebuilds every player's rating from scratch from a history of timestamped matches. Matches are grouped into rating periods by time and each period is rated at once with the RatePeriod function of the elo, glicko, or glicko2 package, so that ratings can be recalculated after correcting the data or changing the system's settings. A replay is deterministic: the same matches and Config always give bit-for-bit identical ratings, whatever order matches with the same Time are given in.
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+)
+
+// ErrUnsupportedSystem is returned when a replay is run with a System other than elo.System, glicko.System, or glicko2.System.
+var ErrUnsupportedSystem = errors.New("replay: unsupported rating system")
+
+// Match is a result between two players identified by ID, played at the given Time. ID identifies the match itself and must be unique if set. Score and Advantage have the same meaning as in rating.Game.
+type Match struct {
+	ID               string
+	Time             time.Time
+	Player, Opponent string
+	Score, Advantage float64
+}
+
+// Config describes how a history of matches is replayed. System is an elo.System, glicko.System, or glicko2.System, and every player starts from its Parameters when they play their first match. Rating period i covers the Times from Start + i*Period up to but not including Start + (i+1)*Period. If Start is zero, the first period starts at the Time of the earliest match, and if Period is zero, every match is rated in a period of its own.
+type Config struct {
+	System rating.System
+	Start  time.Time
+	Period time.Duration
+}
+
+// Ratings is the result of a replay. Exactly one of Elo, Glicko, and Glicko2 is set, depending on the System that was replayed, and holds every player in their state after the last rating period. Periods holds every rating period that contained at least one match, in order.
+type Ratings struct {
+	Periods []Period
+	Elo     map[string]*elo.Player
+	Glicko  map[string]*glicko.Player
+	Glicko2 map[string]*glicko2.Player
+}
+
+// Period is a rating period in a replay. Index counts the periods from Start, including those without any matches. Matches holds the matches rated in the period, in the order they were rated. Outcomes holds the change in every player's state over the period, keyed by player ID, including players who had already played but did not play in this period.
+type Period struct {
+	Index    int
+	Start    time.Time
+	Matches  []Match
+	Outcomes map[string]rating.Outcome
+}
+
+// Replay rates every match in order of Time, as described for Config. Matches with the same Time are ordered by ID, and then by Player, Opponent, Score, and Advantage, so matches without an ID are ordered by their contents rather than the order they were given in. The matches are not modified.
+func (c Config) Replay(matches []Match) (Ratings, error) {
+	return c.replay(matches, nil)
+}
+
+// replay rates the matches as described for Replay, and makes sure that every player in ids appears in the Ratings even if they played no matches.
+func (c Config) replay(matches []Match, ids []string) (Ratings, error) {
+	l, err := newLeague(c.System)
+	if err != nil {
+		return Ratings{}, err
+	}
+	sorted, err := sortMatches(matches)
+	if err != nil {
+		return Ratings{}, err
+	}
+	var r Ratings
+	if len(sorted) > 0 {
+		if c.Start.IsZero() {
+			c.Start = sorted[0].Time
+		}
+		if sorted[0].Time.Before(c.Start) {
+			return Ratings{}, fmt.Errorf("replay: match %q is before the start of the first period", sorted[0].ID)
+		}
+	}
+	last := -1
+	for i := 0; i < len(sorted); {
+		index := c.index(sorted[i], i)
+		j := i
+		for j < len(sorted) && c.index(sorted[j], j) == index {
+			j++
+		}
+		if last >= 0 && index > last+1 {
+			l.skip(index - last - 1)
+		}
+		start := sorted[i].Time
+		if c.Period > 0 {
+			start = c.Start.Add(time.Duration(index) * c.Period)
+		}
+		r.Periods = append(r.Periods, Period{Index: index, Start: start, Matches: sorted[i:j], Outcomes: l.ratePeriod(sorted[i:j])})
+		last = index
+		i = j
+	}
+	for _, id := range ids {
+		l.add(id)
+	}
+	l.fill(&r)
+	return r, nil
+}
+
+// Estimate returns the final Estimate of the player with the given ID, and whether the player appeared in the replay.
+func (r Ratings) Estimate(id string) (rating.Estimate, bool) {
+	if p, ok := r.Elo[id]; ok {
+		return rating.Estimate{Rating: p.Rating}, true
+	}
+	if p, ok := r.Glicko[id]; ok {
+		return rating.Estimate{Rating: p.Rating, Deviation: p.Deviation}, true
+	}
+	if p, ok := r.Glicko2[id]; ok {
+		return rating.Estimate{Rating: p.Rating, Deviation: p.Deviation, Volatility: p.Volatility}, true
+	}
+	return rating.Estimate{}, false
+}
+
+// index returns the rating period of the ith match.
+func (c Config) index(m Match, i int) int {
+	if c.Period <= 0 {
+		return i
+	}
+	return int(m.Time.Sub(c.Start) / c.Period)
+}
+
+func sortMatches(matches []Match) ([]Match, error) {
+	sorted := make([]Match, len(matches))
+	copy(sorted, matches)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		switch {
+		case !a.Time.Equal(b.Time):
+			return a.Time.Before(b.Time)
+		case a.ID != b.ID:
+			return a.ID < b.ID
+		case a.Player != b.Player:
+			return a.Player < b.Player
+		case a.Opponent != b.Opponent:
+			return a.Opponent < b.Opponent
+		case a.Score != b.Score:
+			return a.Score < b.Score
+		}
+		return a.Advantage < b.Advantage
+	})
+	seen := make(map[string]bool, len(sorted))
+	for _, m := range sorted {
+		if m.Player == m.Opponent {
+			return nil, fmt.Errorf("replay: match %q is between %q and themselves", m.ID, m.Player)
+		}
+		if m.ID == "" {
+			continue
+		}
+		if seen[m.ID] {
+			return nil, fmt.Errorf("replay: match %q appears more than once", m.ID)
+		}
+		seen[m.ID] = true
+	}
+	return sorted, nil
+}
@@ -0,0 +1,216 @@
+package replay
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko2"
+	"github.com/dylrich/rating/internal/simulate"
+	"github.com/dylrich/rating/trueskill"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// history returns a simulated history with several matches at the same Time in every period.
+func history() []Match {
+	games := simulate.League{Players: 12, Periods: 8, GamesPerPeriod: 20, Drift: 10, Advantage: 30}.Games(1)
+	matches := make([]Match, len(games))
+	for i, g := range games {
+		matches[i] = Match{
+			ID:        string(rune('A'+i/26)) + string(rune('a'+i%26)),
+			Time:      start.Add(time.Duration(g.Period)*7*24*time.Hour + time.Duration(i%3)*time.Hour),
+			Player:    g.Player,
+			Opponent:  g.Opponent,
+			Score:     g.Score,
+			Advantage: g.Advantage,
+		}
+	}
+	return matches
+}
+
+func TestReplay(t *testing.T) {
+	system := glicko2.System{Parameters: glicko2.Parameters{InitialRating: 1500, InitialDeviation: 350, InitialVolatility: 0.06}, Config: glicko2.DefaultConfig()}
+	system.Config.Advantage = 30
+	matches := history()
+
+	// Leave out the sixth week entirely, so that everyone is inactive for a period.
+	var kept []Match
+	for _, m := range matches {
+		if m.Time.Sub(start) < 5*7*24*time.Hour || m.Time.Sub(start) >= 6*7*24*time.Hour {
+			kept = append(kept, m)
+		}
+	}
+	r, err := Config{System: system, Period: 7 * 24 * time.Hour}.Replay(kept)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The replay matches rating each week by hand.
+	players := make(map[string]*glicko2.Player)
+	var order []*glicko2.Player
+	get := func(id string) *glicko2.Player {
+		if p, ok := players[id]; ok {
+			return p
+		}
+		p := system.Config.NewPlayer(system.Parameters)
+		players[id] = p
+		order = append(order, p)
+		return p
+	}
+	for week := 0; week < 8; week++ {
+		if week == 5 {
+			for _, p := range order {
+				p.NewPeriods(1)
+			}
+			continue
+		}
+		var period []glicko2.Match
+		for _, m := range kept {
+			if int(m.Time.Sub(start)/(7*24*time.Hour)) == week {
+				period = append(period, glicko2.Match{Player: get(m.Player), Opponent: get(m.Opponent), Score: m.Score, Advantage: m.Advantage})
+			}
+		}
+		glicko2.RatePeriod(order, period)
+	}
+	if len(r.Periods) != 7 || r.Periods[5].Index != 6 || !r.Periods[5].Start.Equal(start.Add(6*7*24*time.Hour)) || len(r.Glicko2) != len(players) {
+		t.Log(len(r.Periods), r.Periods[5].Index, r.Periods[5].Start)
+		t.Fail()
+	}
+	for id, p := range players {
+		got := r.Glicko2[id]
+		if got.Rating != p.Rating || got.Deviation != p.Deviation || got.Volatility != p.Volatility {
+			t.Log(id, got, p)
+			t.Fail()
+		}
+		e, ok := r.Estimate(id)
+		if !ok || e.Rating != p.Rating || e.Deviation != p.Deviation || e.Volatility != p.Volatility {
+			t.Log(id, e)
+			t.Fail()
+		}
+	}
+	last := r.Periods[len(r.Periods)-1]
+	if len(last.Outcomes) != len(players) {
+		t.Log(last.Outcomes)
+		t.Fail()
+	}
+	for id, o := range last.Outcomes {
+		if o.Rating != players[id].Rating {
+			t.Log(id, o)
+			t.Fail()
+		}
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	c := Config{System: elo.System{Parameters: elo.Parameters{InitialRating: 1500}, Config: elo.DefaultConfig()}, Period: 7 * 24 * time.Hour}
+	matches := history()
+	first, err := c.Replay(matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Shuffling the input changes the order of matches with the same Time, but not the ratings, down to the last bit.
+	shuffled := make([]Match, len(matches))
+	copy(shuffled, matches)
+	rand.New(rand.NewSource(2)).Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	second, err := c.Replay(shuffled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Fail()
+	}
+	for id, p := range first.Elo {
+		if math.Float64bits(p.Rating) != math.Float64bits(second.Elo[id].Rating) {
+			t.Log(id, p.Rating, second.Elo[id].Rating)
+			t.Fail()
+		}
+	}
+	if !reflect.DeepEqual(matches, history()) {
+		t.Log("the input was modified")
+		t.Fail()
+	}
+
+	// Without IDs and with every match rated on its own, the order of matches with the same Time matters, so they are ordered by their contents.
+	c.Period = 0
+	for i := range matches {
+		matches[i].ID = ""
+	}
+	first, err = c.Replay(matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for seed := int64(0); seed < 5; seed++ {
+		copy(shuffled, matches)
+		rand.New(rand.NewSource(seed)).Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		second, err := c.Replay(shuffled)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(first, second) {
+			t.Log(seed)
+			t.Fail()
+		}
+	}
+}
+
+func TestReplayWithoutPeriods(t *testing.T) {
+	system := elo.System{Parameters: elo.Parameters{InitialRating: 1500}, Config: elo.DefaultConfig()}
+	matches := []Match{
+		{ID: "1", Time: start, Player: "a", Opponent: "b", Score: 1},
+		{ID: "2", Time: start, Player: "b", Opponent: "c", Score: 0.5},
+		{ID: "3", Time: start.Add(time.Hour), Player: "c", Opponent: "a", Score: 1},
+	}
+	r, err := Config{System: system}.Replay(matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every match is its own period, which is ordinary sequential Elo.
+	a, b, c := system.Config.NewPlayer(system.Parameters), system.Config.NewPlayer(system.Parameters), system.Config.NewPlayer(system.Parameters)
+	ar, br := a.Rating, b.Rating
+	a.Win(br)
+	b.Lose(ar)
+	br, cr := b.Rating, c.Rating
+	b.Draw(cr)
+	c.Draw(br)
+	cr, ar = c.Rating, a.Rating
+	c.Win(ar)
+	a.Lose(cr)
+	for id, p := range map[string]*elo.Player{"a": a, "b": b, "c": c} {
+		if r.Elo[id].Rating != p.Rating || r.Elo[id].GamesPlayed != p.GamesPlayed {
+			t.Log(id, r.Elo[id], p)
+			t.Fail()
+		}
+	}
+	if len(r.Periods) != 3 || !r.Periods[2].Start.Equal(start.Add(time.Hour)) {
+		t.Log(r.Periods)
+		t.Fail()
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	system := elo.System{Config: elo.DefaultConfig()}
+	for _, c := range []struct {
+		config  Config
+		matches []Match
+	}{
+		{Config{System: trueskill.System{}}, nil},
+		{Config{System: system}, []Match{{ID: "1", Player: "a", Opponent: "b"}, {ID: "1", Player: "b", Opponent: "a"}}},
+		{Config{System: system}, []Match{{ID: "1", Player: "a", Opponent: "a"}}},
+		{Config{System: system, Start: start}, []Match{{ID: "1", Time: start.Add(-time.Hour), Player: "a", Opponent: "b"}}},
+	} {
+		if _, err := c.config.Replay(c.matches); err == nil {
+			t.Log(c)
+			t.Fail()
+		}
+	}
+	if _, err := (Config{System: trueskill.System{}}).Replay(nil); err != ErrUnsupportedSystem {
+		t.Log(err)
+		t.Fail()
+	}
+}
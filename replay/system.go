@@ -0,0 +1,159 @@
+package replay
+
+import (
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+)
+
+// league holds the players of a replay for a particular rating system. Players are created the first time they appear in a match, and are kept in the order they were created so that every period is rated in the same order.
+type league interface {
+	ratePeriod(matches []Match) map[string]rating.Outcome
+	skip(periods int)
+	add(id string)
+	fill(r *Ratings)
+}
+
+func newLeague(s rating.System) (league, error) {
+	switch s := s.(type) {
+	case elo.System:
+		return &eloLeague{system: s, players: make(map[string]*elo.Player), ids: make(map[*elo.Player]string)}, nil
+	case glicko.System:
+		return &glickoLeague{system: s, players: make(map[string]*glicko.Player), ids: make(map[*glicko.Player]string)}, nil
+	case glicko2.System:
+		return &glicko2League{system: s, players: make(map[string]*glicko2.Player), ids: make(map[*glicko2.Player]string)}, nil
+	}
+	return nil, ErrUnsupportedSystem
+}
+
+type eloLeague struct {
+	system  elo.System
+	players map[string]*elo.Player
+	ids     map[*elo.Player]string
+	order   []*elo.Player
+}
+
+func (l *eloLeague) get(id string) *elo.Player {
+	if p, ok := l.players[id]; ok {
+		return p
+	}
+	p := l.system.Config.NewPlayer(l.system.Parameters)
+	l.players[id] = p
+	l.ids[p] = id
+	l.order = append(l.order, p)
+	return p
+}
+
+func (l *eloLeague) ratePeriod(matches []Match) map[string]rating.Outcome {
+	period := make([]elo.Match, len(matches))
+	for i, m := range matches {
+		period[i] = elo.Match{Player: l.get(m.Player), Opponent: l.get(m.Opponent), Score: m.Score, Advantage: m.Advantage}
+	}
+	outcomes := make(map[string]rating.Outcome, len(l.order))
+	for p, o := range elo.RatePeriod(l.order, period) {
+		outcomes[l.ids[p]] = rating.Outcome{Rating: o.Rating, RatingDelta: o.RatingDelta}
+	}
+	return outcomes
+}
+
+// skip does nothing, because an Elo rating does not change while a player is inactive.
+func (l *eloLeague) skip(periods int) {}
+
+func (l *eloLeague) add(id string) {
+	l.get(id)
+}
+
+func (l *eloLeague) fill(r *Ratings) {
+	r.Elo = l.players
+}
+
+type glickoLeague struct {
+	system  glicko.System
+	players map[string]*glicko.Player
+	ids     map[*glicko.Player]string
+	order   []*glicko.Player
+}
+
+func (l *glickoLeague) get(id string) *glicko.Player {
+	if p, ok := l.players[id]; ok {
+		return p
+	}
+	p := l.system.Config.NewPlayer(l.system.Parameters)
+	l.players[id] = p
+	l.ids[p] = id
+	l.order = append(l.order, p)
+	return p
+}
+
+func (l *glickoLeague) ratePeriod(matches []Match) map[string]rating.Outcome {
+	period := make([]glicko.Match, len(matches))
+	for i, m := range matches {
+		period[i] = glicko.Match{Player: l.get(m.Player), Opponent: l.get(m.Opponent), Score: m.Score, Advantage: m.Advantage}
+	}
+	outcomes := make(map[string]rating.Outcome, len(l.order))
+	for p, o := range glicko.RatePeriod(l.order, period) {
+		outcomes[l.ids[p]] = rating.Outcome{Rating: o.Rating, RatingDelta: o.RatingDelta, Deviation: o.Deviation, DeviationDelta: o.DeviationDelta}
+	}
+	return outcomes
+}
+
+// skip closes the given number of periods in which nobody played, so that every player's Deviation grows.
+func (l *glickoLeague) skip(periods int) {
+	for _, p := range l.order {
+		p.NewPeriods(periods)
+	}
+}
+
+func (l *glickoLeague) add(id string) {
+	l.get(id)
+}
+
+func (l *glickoLeague) fill(r *Ratings) {
+	r.Glicko = l.players
+}
+
+type glicko2League struct {
+	system  glicko2.System
+	players map[string]*glicko2.Player
+	ids     map[*glicko2.Player]string
+	order   []*glicko2.Player
+}
+
+func (l *glicko2League) get(id string) *glicko2.Player {
+	if p, ok := l.players[id]; ok {
+		return p
+	}
+	p := l.system.Config.NewPlayer(l.system.Parameters)
+	l.players[id] = p
+	l.ids[p] = id
+	l.order = append(l.order, p)
+	return p
+}
+
+func (l *glicko2League) ratePeriod(matches []Match) map[string]rating.Outcome {
+	period := make([]glicko2.Match, len(matches))
+	for i, m := range matches {
+		period[i] = glicko2.Match{Player: l.get(m.Player), Opponent: l.get(m.Opponent), Score: m.Score, Advantage: m.Advantage}
+	}
+	outcomes := make(map[string]rating.Outcome, len(l.order))
+	for p, o := range glicko2.RatePeriod(l.order, period) {
+		outcomes[l.ids[p]] = rating.Outcome{Rating: o.Rating, RatingDelta: o.RatingDelta, Deviation: o.Deviation, DeviationDelta: o.DeviationDelta, Volatility: o.Volatility, VolatilityDelta: o.VolatilityDelta}
+	}
+	return outcomes
+}
+
+// skip closes the given number of periods in which nobody played, so that every player's Deviation grows.
+func (l *glicko2League) skip(periods int) {
+	for _, p := range l.order {
+		p.NewPeriods(periods)
+	}
+}
+
+func (l *glicko2League) add(id string) {
+	l.get(id)
+}
+
+func (l *glicko2League) fill(r *Ratings) {
+	r.Glicko2 = l.players
+}
@@ -0,0 +1,42 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+)
+
+func TestInactivity(t *testing.T) {
+	matches := []Match{
+		{ID: "1", Time: start, Player: "a", Opponent: "b", Score: 1},
+		{ID: "2", Time: start.Add(10 * 24 * time.Hour), Player: "a", Opponent: "b", Score: 1},
+	}
+
+	// Glicko deviations grow through the nine idle days between the matches.
+	g, err := Config{System: glicko.System{Parameters: glicko.Parameters{InitialRating: 1500, InitialDeviation: 200}, Config: glicko.DefaultConfig()}, Period: 24 * time.Hour}.Replay(matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := glicko.NewPlayer(glicko.Parameters{InitialRating: 1500, InitialDeviation: 200})
+	q := glicko.NewPlayer(glicko.Parameters{InitialRating: 1500, InitialDeviation: 200})
+	glicko.RatePeriod(nil, []glicko.Match{{Player: p, Opponent: q, Score: 1}})
+	p.NewPeriods(9)
+	q.NewPeriods(9)
+	glicko.RatePeriod(nil, []glicko.Match{{Player: p, Opponent: q, Score: 1}})
+	if g.Periods[1].Index != 10 || g.Glicko["a"].Rating != p.Rating || g.Glicko["a"].Deviation != p.Deviation || g.Glicko["b"].Deviation != q.Deviation {
+		t.Log(g.Periods[1], g.Glicko["a"], p)
+		t.Fail()
+	}
+
+	// Elo ratings do not change while idle.
+	e, err := Config{System: elo.System{Parameters: elo.Parameters{InitialRating: 1500}, Config: elo.DefaultConfig()}, Period: 24 * time.Hour}.Replay(matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Elo["a"].GamesPlayed != 2 || e.Periods[1].Outcomes["a"].RatingDelta <= 0 {
+		t.Log(e.Elo["a"], e.Periods[1])
+		t.Fail()
+	}
+}
@@ -0,0 +1,36 @@
+package rating
+
+import (
+	"math"
+)
+
+// Interval returns the range that contains the true rating with the given probability, such as 0.95 for a 95% interval. The range is Rating ± z·Deviation, where z is the standard normal quantile for the level.
+func (e Estimate) Interval(level float64) (float64, float64) {
+	z := math.Sqrt2 * math.Erfinv(level)
+	return e.Rating - z*e.Deviation, e.Rating + z*e.Deviation
+}
+
+// Conservative returns a conservative display rating of Rating - k·Deviation, which is unlikely to overstate the player's skill. With k = 2, the true rating is above it about 97.7% of the time. Ranking by the conservative rating keeps players with few results from topping a leaderboard on the strength of a lucky start.
+func (e Estimate) Conservative(k float64) float64 {
+	return e.Rating - k*e.Deviation
+}
+
+// Provisional reports whether the Deviation is above the given threshold, in which case the rating should not yet be relied on.
+func (e Estimate) Provisional(threshold float64) bool {
+	return e.Deviation > threshold
+}
+
+// ProbabilityStronger returns the probability that the true rating is higher than that of the opponent, treating both ratings as normally distributed. Unlike an expected score, it compares the players' skills rather than predicting the result of a single match, so it approaches 1 as both deviations shrink, however small the gap between the ratings.
+func (e Estimate) ProbabilityStronger(opponent Estimate) float64 {
+	spread := math.Hypot(e.Deviation, opponent.Deviation)
+	if spread == 0 {
+		switch {
+		case e.Rating > opponent.Rating:
+			return 1
+		case e.Rating < opponent.Rating:
+			return 0
+		}
+		return 0.5
+	}
+	return math.Erfc(-(e.Rating-opponent.Rating)/spread/math.Sqrt2) / 2
+}
@@ -0,0 +1,45 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInterval(t *testing.T) {
+	e := Estimate{Rating: 1500, Deviation: 100}
+	low, high := e.Interval(0.95)
+	if math.Abs(low-(1500-195.996)) > 0.001 || math.Abs(high-(1500+195.996)) > 0.001 {
+		t.Log(low, high)
+		t.Fail()
+	}
+	low, high = e.Interval(0.6827)
+	if math.Abs(low-1400) > 0.01 || math.Abs(high-1600) > 0.01 {
+		t.Log(low, high)
+		t.Fail()
+	}
+	if c := e.Conservative(2); c != 1300 {
+		t.Log(c)
+		t.Fail()
+	}
+	if !e.Provisional(80) || e.Provisional(100) {
+		t.Fail()
+	}
+}
+
+func TestProbabilityStronger(t *testing.T) {
+	e := Estimate{Rating: 1600, Deviation: 30}
+
+	// The combined deviation of 30 and 40 is 50, so a 100 point lead is two standard deviations.
+	if s := e.ProbabilityStronger(Estimate{Rating: 1500, Deviation: 40}); math.Abs(s-0.97725) > 0.00001 {
+		t.Log(s)
+		t.Fail()
+	}
+	if s := e.ProbabilityStronger(Estimate{Rating: 1600, Deviation: 40}); s != 0.5 {
+		t.Log(s)
+		t.Fail()
+	}
+	e.Deviation = 0
+	if e.ProbabilityStronger(Estimate{Rating: 1599}) != 1 || e.ProbabilityStronger(Estimate{Rating: 1601}) != 0 {
+		t.Fail()
+	}
+}
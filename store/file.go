@@ -0,0 +1,104 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+)
+
+// File is a Store that keeps every player in a single JSON file. Each Save rewrites the whole file by writing a temporary file alongside it and renaming it into place, so the file always holds either the old or the new contents, even if the process crashes part way through. File is safe for concurrent use within a process, but the file must not be shared between processes.
+type File struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFile returns a File store that reads and writes the file at the given path. The file is created on the first Save if it does not exist.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Load returns the player stored under the ID.
+func (f *File) Load(id string) (Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	records, err := f.read()
+	if err != nil {
+		return Record{}, err
+	}
+	data, ok := records[id]
+	if !ok {
+		return Record{}, ErrNotFound
+	}
+	return decode(data)
+}
+
+// Save stores the Record if its Version matches the stored Version.
+func (f *File) Save(r Record) (Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	records, err := f.read()
+	if err != nil {
+		return Record{}, err
+	}
+	current := 0
+	if data, ok := records[r.ID]; ok {
+		stored, err := decode(data)
+		if err != nil {
+			return Record{}, err
+		}
+		current = stored.Version
+	}
+	if r.Version != current {
+		return Record{}, ErrConflict
+	}
+	r.Version++
+	data, err := encode(r)
+	if err != nil {
+		return Record{}, err
+	}
+	records[r.ID] = data
+	if err := f.write(records); err != nil {
+		return Record{}, err
+	}
+	return decode(data)
+}
+
+// IDs returns the IDs of every stored player in ascending order.
+func (f *File) IDs() ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	records, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(records))
+	for id := range records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (f *File) read() (map[string]json.RawMessage, error) {
+	records := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (f *File) write(records map[string]json.RawMessage) error {
+	data, err := json.MarshalIndent(records, "", "\t")
+	if err != nil {
+		return err
+	}
+	return writeFile(f.path, data)
+}
@@ -0,0 +1,726 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dylrich/rating"
+)
+
+const (
+
+	// DefaultSegmentSize is the standard value for LogConfig.SegmentSize.
+	DefaultSegmentSize = 16 << 20
+
+	// DefaultSnapshotInterval is the standard value for LogConfig.SnapshotInterval.
+	DefaultSnapshotInterval = 10000
+)
+
+// ErrCorrupt is returned when a Log cannot be recovered because an entry other than the last one in the log is damaged, or because entries are missing.
+var ErrCorrupt = errors.New("store: log is corrupt")
+
+// errTorn is returned by unframe when an entry runs past the end of the data, as left behind by a crash part way through an append.
+var errTorn = errors.New("truncated entry")
+
+// LogConfig contains the settings for a Log. Any zero field is replaced with its package default when the LogConfig is used to open a Log.
+type LogConfig struct {
+	// SegmentSize is the size in bytes a segment file may reach before the Log moves on to a new one.
+	SegmentSize int64
+
+	// SnapshotInterval is the number of entries that may be appended after the latest snapshot before the Log writes a new snapshot and compacts itself.
+	SnapshotInterval int
+}
+
+// Log is a Store that keeps players by event sourcing. Every change, whether a saved player, a recorded, voided, or amended match, or the start of a new rating period, is appended to a write-ahead log of segment files in a directory and synced to disk before it is applied, and the players are rebuilt by replaying the log when it is opened. Each entry carries a sequence number and a checksum. Periodic snapshots of every player are written next to the log, so that only the entries after the latest snapshot are replayed, and compaction removes the segments and snapshots that a newer snapshot has made obsolete. If the process crashes part way through appending an entry, the torn entry is discarded when the log is next opened, and entries already covered by a snapshot are never applied twice. Matches are rated, voided, and amended in the same way as by SQL. Log is safe for concurrent use within a process, but the directory must not be shared between processes.
+type Log struct {
+	mu       sync.Mutex
+	dir      string
+	config   LogConfig
+	records  map[string][]byte
+	matches  map[string]bool
+	history  map[string][]string
+	sequence uint64
+	snapshot uint64
+	segment  *os.File
+	size     int64
+	err      error
+}
+
+// event is a single entry in a Log. Exactly one of Save, Match, Void, Amend, and NewPeriod is set.
+type event struct {
+	Sequence  uint64
+	Save      json.RawMessage `json:",omitempty"`
+	Match     *Match          `json:",omitempty"`
+	Void      string          `json:",omitempty"`
+	Amend     *amendment      `json:",omitempty"`
+	NewPeriod bool            `json:",omitempty"`
+}
+
+// amendment is the new score of a recorded match for one of its players, as passed to Log.Amend.
+type amendment struct {
+	Match, Player string
+	Score         float64
+}
+
+// snapshot is the state of a Log after the entry with the given Sequence has been applied. History holds the match ID of each result in every player's History for the current rating period, or an empty string for a result that was saved rather than recorded.
+type snapshot struct {
+	Sequence uint64
+	Players  map[string]json.RawMessage
+	Matches  []string
+	History  map[string][]string `json:",omitempty"`
+}
+
+// DefaultLogConfig returns a LogConfig populated with the package default values.
+func DefaultLogConfig() LogConfig {
+	return LogConfig{SegmentSize: DefaultSegmentSize, SnapshotInterval: DefaultSnapshotInterval}
+}
+
+// OpenLog opens the Log in the given directory using DefaultLogConfig, creating it if it does not exist.
+func OpenLog(dir string) (*Log, error) {
+	return DefaultLogConfig().OpenLog(dir)
+}
+
+// OpenLog opens the Log in the given directory, creating it if it does not exist. The latest snapshot is loaded and the entries after it are replayed. A torn entry at the end of the last segment is removed, while any other damaged or missing entry returns ErrCorrupt.
+func (c LogConfig) OpenLog(dir string) (*Log, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	l := &Log{dir: dir, config: c.withDefaults(), records: make(map[string][]byte), matches: make(map[string]bool), history: make(map[string][]string)}
+	if err := l.recover(); err != nil {
+		if l.segment != nil {
+			l.segment.Close()
+		}
+		return nil, err
+	}
+	return l, nil
+}
+
+// Load returns the player stored under the ID.
+func (l *Log) Load(id string) (Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	data, ok := l.records[id]
+	if !ok {
+		return Record{}, ErrNotFound
+	}
+	return decode(data)
+}
+
+// Save stores the Record if its Version matches the stored Version.
+func (l *Log) Save(r Record) (Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	current := 0
+	if data, ok := l.records[r.ID]; ok {
+		stored, err := decode(data)
+		if err != nil {
+			return Record{}, err
+		}
+		current = stored.Version
+	}
+	if r.Version != current {
+		return Record{}, ErrConflict
+	}
+	r.Version++
+	data, err := encode(r)
+	if err != nil {
+		return Record{}, err
+	}
+	if _, err := l.append(event{Save: data}); err != nil {
+		return Record{}, err
+	}
+	return decode(data)
+}
+
+// IDs returns the IDs of every stored player in ascending order.
+func (l *Log) IDs() ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.ids(), nil
+}
+
+// Record rates a match between two stored players and returns the Outcome for each. Each player is rated against the other's rating from before the match using Play. The match is only applied once it is safely in the log, and a match ID can only be recorded once.
+func (l *Log) Record(m Match) (rating.Outcome, rating.Outcome, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	outcomes, err := l.append(event{Match: &m})
+	if err != nil {
+		return rating.Outcome{}, rating.Outcome{}, err
+	}
+	return outcomes[m.Player], outcomes[m.Opponent], nil
+}
+
+// Void removes a recorded match from the History of both of its players and recalculates their ratings for the current rating period, returning the change in each player's state keyed by player ID. Void returns ErrMatchNotFound if no match is recorded under the ID, and ErrClosedPeriod if the match was played in an earlier rating period. Once voided, the match ID may be recorded again.
+func (l *Log) Void(matchID string) (map[string]rating.Outcome, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.append(event{Void: matchID})
+}
+
+// Amend replaces the result of a recorded match and recalculates the ratings of both of its players for the current rating period, returning the change caused by the amendment keyed by player ID. The score is the result for the player with the given ID, and their opponent receives the complementary score. Amend returns the same errors as Void, and ErrMatchNotFound if the player did not play in the match.
+func (l *Log) Amend(matchID, playerID string, score float64) (map[string]rating.Outcome, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.append(event{Amend: &amendment{Match: matchID, Player: playerID, Score: score}})
+}
+
+// NewPeriod calls NewPeriod on every stored player.
+func (l *Log) NewPeriod() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err := l.append(event{NewPeriod: true})
+	return err
+}
+
+// Snapshot writes a snapshot of every player and starts a new segment, so that the entries before it no longer need to be replayed. Snapshots are written automatically every LogConfig.SnapshotInterval entries, but may also be written by hand, e.g. before a planned shutdown.
+func (l *Log) Snapshot() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.writeSnapshot()
+}
+
+// Compact removes every segment whose entries are all covered by the latest snapshot, along with every older snapshot.
+func (l *Log) Compact() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.compact()
+}
+
+// Close closes the current segment. The Log must not be used after it is closed.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err == nil {
+		l.err = errors.New("store: log is closed")
+	}
+	return l.segment.Close()
+}
+
+// append writes the event to the log and applies it once it is on disk. Nothing is written if the event cannot be applied.
+func (l *Log) append(e event) (map[string]rating.Outcome, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	if l.sequence-l.snapshot >= uint64(l.config.SnapshotInterval) {
+		if err := l.writeSnapshot(); err != nil {
+			return nil, err
+		}
+		if err := l.compact(); err != nil {
+			return nil, err
+		}
+	}
+	e.Sequence = l.sequence + 1
+	changes, outcomes, err := l.apply(e)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	if l.size >= l.config.SegmentSize {
+		if err := l.rotate(e.Sequence); err != nil {
+			return nil, err
+		}
+	}
+	if err := l.write(data); err != nil {
+		return nil, err
+	}
+	l.commit(e, changes)
+	return outcomes, nil
+}
+
+// apply works out the players changed by the event without changing the Log, so that an event is only committed once it has been written.
+func (l *Log) apply(e event) (map[string][]byte, map[string]rating.Outcome, error) {
+	outcomes := make(map[string]rating.Outcome)
+	changes := make(map[string][]byte)
+	switch {
+	case e.Save != nil:
+		r, err := decode(e.Save)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes[r.ID] = e.Save
+	case e.Match != nil:
+		m := *e.Match
+		if l.matches[m.ID] {
+			return nil, nil, ErrDuplicateMatch
+		}
+		var records [2]Record
+		for i, id := range []string{m.Player, m.Opponent} {
+			data, ok := l.records[id]
+			if !ok {
+				return nil, nil, ErrNotFound
+			}
+			r, err := decode(data)
+			if err != nil {
+				return nil, nil, err
+			}
+			records[i] = r
+		}
+		a, b, err := play(records[0], records[1], m)
+		if err != nil {
+			return nil, nil, err
+		}
+		outcomes[m.Player], outcomes[m.Opponent] = a, b
+		for _, r := range records {
+			r.Version++
+			data, err := encode(r)
+			if err != nil {
+				return nil, nil, err
+			}
+			changes[r.ID] = data
+		}
+	case e.Void != "" || e.Amend != nil:
+		matchID, playerID := e.Void, ""
+		if e.Amend != nil {
+			matchID, playerID = e.Amend.Match, e.Amend.Player
+		}
+		players, positions, err := l.locate(matchID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if playerID != "" && playerID != players[0] && playerID != players[len(players)-1] {
+			return nil, nil, ErrMatchNotFound
+		}
+		for i, id := range players {
+			r, err := decode(l.records[id])
+			if err != nil {
+				return nil, nil, err
+			}
+			var o rating.Outcome
+			switch {
+			case e.Amend == nil:
+				o, err = r.void(positions[i])
+			case id == playerID:
+				o, err = r.amend(positions[i], e.Amend.Score)
+			default:
+				o, err = r.amend(positions[i], 1-e.Amend.Score)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			r.Version++
+			data, err := encode(r)
+			if err != nil {
+				return nil, nil, err
+			}
+			changes[id], outcomes[id] = data, o
+		}
+	case e.NewPeriod:
+		for _, id := range l.ids() {
+			r, err := decode(l.records[id])
+			if err != nil {
+				return nil, nil, err
+			}
+			r.newPeriod()
+			r.Version++
+			data, err := encode(r)
+			if err != nil {
+				return nil, nil, err
+			}
+			changes[id] = data
+		}
+	}
+	return changes, outcomes, nil
+}
+
+// commit applies the changes worked out by apply, and keeps track of which recorded match each result in a player's History for the current rating period came from.
+func (l *Log) commit(e event, changes map[string][]byte) {
+	switch {
+	case e.Save != nil:
+		for id, data := range changes {
+			if r, err := decode(data); err == nil {
+				l.history[id] = resize(l.history[id], r.length())
+			}
+		}
+	case e.Match != nil:
+		l.matches[e.Match.ID] = true
+		for _, id := range []string{e.Match.Player, e.Match.Opponent} {
+			l.history[id] = append(l.history[id], e.Match.ID)
+		}
+	case e.Void != "":
+		players, positions, _ := l.locate(e.Void)
+		for i, id := range players {
+			history := make([]string, 0, len(l.history[id])-1)
+			history = append(history, l.history[id][:positions[i]]...)
+			l.history[id] = append(history, l.history[id][positions[i]+1:]...)
+		}
+		delete(l.matches, e.Void)
+	case e.NewPeriod:
+		l.history = make(map[string][]string)
+	}
+	for id, data := range changes {
+		l.records[id] = data
+	}
+	l.sequence = e.Sequence
+}
+
+// locate returns the players of a recorded match in ascending order of ID, along with the index of the match in each player's History. It returns ErrClosedPeriod if the match was recorded in an earlier rating period.
+func (l *Log) locate(matchID string) ([]string, []int, error) {
+	if matchID == "" {
+		return nil, nil, ErrMatchNotFound
+	}
+	var players []string
+	var positions []int
+	for _, id := range l.ids() {
+		for i, m := range l.history[id] {
+			if m == matchID {
+				players, positions = append(players, id), append(positions, i)
+				break
+			}
+		}
+	}
+	if len(players) == 0 {
+		if l.matches[matchID] {
+			return nil, nil, ErrClosedPeriod
+		}
+		return nil, nil, ErrMatchNotFound
+	}
+	return players, positions, nil
+}
+
+// resize returns the match IDs cut or padded with empty strings to the given length, so that a saved player keeps the match IDs of the results they already had.
+func resize(matches []string, n int) []string {
+	if len(matches) > n {
+		return matches[:n]
+	}
+	for len(matches) < n {
+		matches = append(matches, "")
+	}
+	return matches
+}
+
+func (l *Log) ids() []string {
+	ids := make([]string, 0, len(l.records))
+	for id := range l.records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// write appends a framed entry to the current segment and syncs it. If the write fails, the segment is cut back to its previous size so that later entries are not written after a torn one. If that also fails, the Log refuses any further writes.
+func (l *Log) write(data []byte) error {
+	if _, err := l.segment.Write(frame(data)); err != nil {
+		l.truncate()
+		return err
+	}
+	if err := l.segment.Sync(); err != nil {
+		l.truncate()
+		return err
+	}
+	l.size += int64(len(data)) + headerSize
+	return nil
+}
+
+func (l *Log) truncate() {
+	if err := l.segment.Truncate(l.size); err != nil {
+		l.err = fmt.Errorf("store: log segment could not be repaired: %v", err)
+	}
+}
+
+// rotate closes the current segment and starts a new one whose first entry has the given sequence number.
+func (l *Log) rotate(sequence uint64) error {
+	f, err := os.OpenFile(filepath.Join(l.dir, segmentName(sequence)), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if err := syncDir(l.dir); err != nil {
+		f.Close()
+		return err
+	}
+	if l.segment != nil {
+		l.segment.Close()
+	}
+	l.segment, l.size = f, 0
+	return nil
+}
+
+func (l *Log) writeSnapshot() error {
+	s := snapshot{Sequence: l.sequence, Players: make(map[string]json.RawMessage, len(l.records)), Matches: make([]string, 0, len(l.matches))}
+	for id, data := range l.records {
+		s.Players[id] = data
+	}
+	for id := range l.matches {
+		s.Matches = append(s.Matches, id)
+	}
+	sort.Strings(s.Matches)
+	if len(l.history) > 0 {
+		s.History = make(map[string][]string, len(l.history))
+		for id, matches := range l.history {
+			s.History[id] = matches
+		}
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(l.dir, snapshotName(s.Sequence)), frame(data)); err != nil {
+		return err
+	}
+	l.snapshot = s.Sequence
+	if l.size > 0 {
+		return l.rotate(s.Sequence + 1)
+	}
+	return nil
+}
+
+func (l *Log) compact() error {
+	segments, snapshots, err := l.list()
+	if err != nil {
+		return err
+	}
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i+1] <= l.snapshot+1 {
+			if err := os.Remove(filepath.Join(l.dir, segmentName(segments[i]))); err != nil {
+				return err
+			}
+		}
+	}
+	for _, s := range snapshots {
+		if s < l.snapshot {
+			if err := os.Remove(filepath.Join(l.dir, snapshotName(s))); err != nil {
+				return err
+			}
+		}
+	}
+	return syncDir(l.dir)
+}
+
+// recover loads the latest readable snapshot and replays the entries after it, leaving the last segment open for appending.
+func (l *Log) recover() error {
+	segments, snapshots, err := l.list()
+	if err != nil {
+		return err
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if l.loadSnapshot(snapshots[i]) == nil {
+			break
+		}
+	}
+	for i, start := range segments {
+		last := i == len(segments)-1
+		if !last && segments[i+1] <= l.sequence+1 {
+			continue
+		}
+		size, err := l.replay(start, last)
+		if err != nil {
+			return err
+		}
+		if last {
+			if err := l.rotate(start); err != nil {
+				return err
+			}
+			l.size = size
+		}
+	}
+	if l.segment == nil {
+		return l.rotate(l.sequence + 1)
+	}
+	return nil
+}
+
+func (l *Log) loadSnapshot(sequence uint64) error {
+	data, err := os.ReadFile(filepath.Join(l.dir, snapshotName(sequence)))
+	if err != nil {
+		return err
+	}
+	payload, _, err := unframe(bufio.NewReader(bytes.NewReader(data)), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	var s snapshot
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return err
+	}
+	if s.Sequence != sequence {
+		return ErrCorrupt
+	}
+	for id, data := range s.Players {
+		l.records[id] = data
+	}
+	for _, id := range s.Matches {
+		l.matches[id] = true
+	}
+	for id, matches := range s.History {
+		l.history[id] = matches
+	}
+	l.sequence, l.snapshot = s.Sequence, s.Sequence
+	return nil
+}
+
+// replay applies the entries in a segment that are not covered by the snapshot, and returns the size of the valid part of the segment. A torn entry at the end of the last segment is cut off, which is only the case if it runs past the end of the segment or nothing follows it.
+func (l *Log) replay(start uint64, last bool) (int64, error) {
+	path := filepath.Join(l.dir, segmentName(start))
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	r := bufio.NewReader(f)
+	var size int64
+	previous := start - 1
+	for {
+		data, n, err := unframe(r, info.Size()-size)
+		if err == io.EOF {
+			return size, nil
+		}
+		var e event
+		if err == nil {
+			err = json.Unmarshal(data, &e)
+		}
+		if err != nil {
+			if last && (err == errTorn || size+n == info.Size()) {
+				return size, os.Truncate(path, size)
+			}
+			return 0, fmt.Errorf("%w: %s at offset %d: %v", ErrCorrupt, filepath.Base(path), size, err)
+		}
+		if e.Sequence != previous+1 || e.Sequence > l.sequence+1 {
+			return 0, fmt.Errorf("%w: %s at offset %d: unexpected entry %d", ErrCorrupt, filepath.Base(path), size, e.Sequence)
+		}
+		previous = e.Sequence
+		size += n
+		if e.Sequence <= l.sequence {
+			continue
+		}
+		changes, _, err := l.apply(e)
+		if err != nil {
+			return 0, fmt.Errorf("%w: entry %d cannot be applied: %v", ErrCorrupt, e.Sequence, err)
+		}
+		l.commit(e, changes)
+	}
+}
+
+// list returns the starting sequence numbers of the segments and the sequence numbers of the snapshots in the Log's directory in ascending order. Temporary files left behind by a crash are removed.
+func (l *Log) list() ([]uint64, []uint64, error) {
+	files, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	var segments, snapshots []uint64
+	for _, f := range files {
+		name := f.Name()
+		switch {
+		case strings.HasSuffix(name, ".log"):
+			if n, err := strconv.ParseUint(strings.TrimSuffix(name, ".log"), 10, 64); err == nil {
+				segments = append(segments, n)
+			}
+		case strings.HasPrefix(name, "snapshot-") && strings.HasSuffix(name, ".json"):
+			if n, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, "snapshot-"), ".json"), 10, 64); err == nil {
+				snapshots = append(snapshots, n)
+			}
+		case strings.Contains(name, ".tmp"):
+			os.Remove(filepath.Join(l.dir, name))
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i] < segments[j] })
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i] < snapshots[j] })
+	return segments, snapshots, nil
+}
+
+func (c LogConfig) withDefaults() LogConfig {
+	if c.SegmentSize == 0 {
+		c.SegmentSize = DefaultSegmentSize
+	}
+	if c.SnapshotInterval == 0 {
+		c.SnapshotInterval = DefaultSnapshotInterval
+	}
+	return c
+}
+
+func segmentName(sequence uint64) string {
+	return fmt.Sprintf("%020d.log", sequence)
+}
+
+func snapshotName(sequence uint64) string {
+	return fmt.Sprintf("snapshot-%020d.json", sequence)
+}
+
+// headerSize is the size of the header in front of every framed entry, which holds the length of the payload and its CRC-32C checksum.
+const headerSize = 8
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+func frame(data []byte) []byte {
+	b := make([]byte, headerSize+len(data))
+	binary.BigEndian.PutUint32(b[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(b[4:8], crc32.Checksum(data, castagnoli))
+	copy(b[headerSize:], data)
+	return b
+}
+
+// unframe reads a framed entry from a reader with the given number of bytes remaining, and returns its payload and the size of the entry. It returns io.EOF only if there are no bytes left at all, and errTorn if the entry runs past the remaining bytes, before anything is allocated for its payload. The size is also returned with a checksum error, so that the caller can tell whether anything follows the entry.
+func unframe(r *bufio.Reader, remaining int64) ([]byte, int64, error) {
+	if remaining == 0 {
+		return nil, 0, io.EOF
+	}
+	header := make([]byte, headerSize)
+	if remaining < headerSize {
+		return nil, 0, errTorn
+	}
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, 0, err
+	}
+	length := int64(binary.BigEndian.Uint32(header[0:4]))
+	if length > remaining-headerSize {
+		return nil, 0, errTorn
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, 0, err
+	}
+	if crc32.Checksum(data, castagnoli) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, length + headerSize, errors.New("checksum mismatch")
+	}
+	return data, length + headerSize, nil
+}
+
+// writeFile replaces the file at the path with the data by writing a temporary file alongside it and renaming it into place.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
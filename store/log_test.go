@@ -0,0 +1,308 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dylrich/rating/glicko2"
+)
+
+func openLog(t *testing.T, c LogConfig, dir string) *Log {
+	l, err := c.OpenLog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+// fill saves three Glicko-2 players and records a round of matches between them.
+func fill(t *testing.T, l *Log) {
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := l.Save(Record{ID: id, Glicko2: glicko2.NewPlayer(glicko2.Parameters{InitialRating: 1500, InitialDeviation: 200, InitialVolatility: 0.06})}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, m := range []Match{
+		{ID: "1", Player: "a", Opponent: "b", Score: 1},
+		{ID: "2", Player: "b", Opponent: "c", Score: 0.5, Advantage: 1},
+		{ID: "3", Player: "c", Opponent: "a", Score: 1},
+	} {
+		if _, _, err := l.Record(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.NewPeriod(); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := l.Record(Match{ID: "4", Player: "a", Opponent: "b", Score: 0}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func contents(t *testing.T, l *Log) map[string]Record {
+	records := make(map[string]Record)
+	ids, _ := l.IDs()
+	for _, id := range ids {
+		r, err := l.Load(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records[id] = r
+	}
+	return records
+}
+
+func TestLog(t *testing.T) {
+	l := openLog(t, LogConfig{}, t.TempDir())
+	defer l.Close()
+	testStore(t, l)
+}
+
+func TestLogRecover(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, LogConfig{}, dir)
+	fill(t, l)
+	want := contents(t, l)
+	l.Close()
+
+	l = openLog(t, LogConfig{}, dir)
+	defer l.Close()
+	if got := contents(t, l); !reflect.DeepEqual(got, want) {
+		t.Log(got, want)
+		t.Fail()
+	}
+	if want["a"].Version != 5 || len(want["a"].Glicko2.History) != 1 {
+		t.Log(want["a"])
+		t.Fail()
+	}
+
+	// A match that was recorded before the restart cannot be recorded again.
+	if _, _, err := l.Record(Match{ID: "2", Player: "a", Opponent: "c", Score: 1}); err != ErrDuplicateMatch {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestLogTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, LogConfig{}, dir)
+	fill(t, l)
+	want := contents(t, l)
+	l.Close()
+
+	// A crash part way through an append leaves half an entry at the end of the last segment.
+	segments, _, _ := (&Log{dir: dir}).list()
+	path := filepath.Join(dir, segmentName(segments[len(segments)-1]))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	torn := frame([]byte(`{"Sequence":9,"Match":{"ID":"5","Player":"a","Opponent":"b","Score":1}}`))
+	f.Write(torn[:len(torn)-10])
+	f.Close()
+
+	l = openLog(t, LogConfig{}, dir)
+	if got := contents(t, l); !reflect.DeepEqual(got, want) {
+		t.Log(got, want)
+		t.Fail()
+	}
+
+	// The torn entry is cut off, so entries appended after recovery are replayed.
+	if _, _, err := l.Record(Match{ID: "5", Player: "a", Opponent: "b", Score: 1}); err != nil {
+		t.Fatal(err)
+	}
+	want = contents(t, l)
+	l.Close()
+	l = openLog(t, LogConfig{}, dir)
+	defer l.Close()
+	if got := contents(t, l); !reflect.DeepEqual(got, want) {
+		t.Log(got, want)
+		t.Fail()
+	}
+}
+
+func TestLogCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	c := LogConfig{SegmentSize: 1}
+	l := openLog(t, c, dir)
+	fill(t, l)
+	l.Close()
+
+	// Damage to any segment but the last cannot be a torn write, so the log refuses to open rather than lose results.
+	segments, _, _ := (&Log{dir: dir}).list()
+	if len(segments) != 8 {
+		t.Fatal(segments)
+	}
+	path := filepath.Join(dir, segmentName(segments[3]))
+	data, _ := os.ReadFile(path)
+	data[len(data)-2] ^= 0xff
+	os.WriteFile(path, data, 0644)
+	if _, err := c.OpenLog(dir); !errors.Is(err, ErrCorrupt) {
+		t.Log(err)
+		t.Fail()
+	}
+
+	// So does a missing segment.
+	os.Remove(path)
+	if _, err := c.OpenLog(dir); !errors.Is(err, ErrCorrupt) {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestLogCorruptTail(t *testing.T) {
+	dir := t.TempDir()
+	l := openLog(t, LogConfig{}, dir)
+	fill(t, l)
+	want := contents(t, l)
+	l.Close()
+	segments, _, _ := (&Log{dir: dir}).list()
+	path := filepath.Join(dir, segmentName(segments[len(segments)-1]))
+	original, _ := os.ReadFile(path)
+
+	// Damage to an entry in the middle of the last segment is not a torn write, because valid entries follow it.
+	data := append([]byte(nil), original...)
+	data[headerSize+2] ^= 0xff
+	os.WriteFile(path, data, 0644)
+	if _, err := (LogConfig{}).OpenLog(dir); !errors.Is(err, ErrCorrupt) {
+		t.Log(err)
+		t.Fail()
+	}
+	if info, _ := os.Stat(path); info.Size() != int64(len(original)) {
+		t.Log(info.Size(), len(original))
+		t.Fail()
+	}
+
+	// A header at the end of the segment that claims a huge entry is torn, and is cut off without allocating for it.
+	data = append(append([]byte(nil), original...), 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, '{')
+	os.WriteFile(path, data, 0644)
+	l = openLog(t, LogConfig{}, dir)
+	defer l.Close()
+	if got := contents(t, l); !reflect.DeepEqual(got, want) {
+		t.Log(got, want)
+		t.Fail()
+	}
+	if info, _ := os.Stat(path); info.Size() != int64(len(original)) {
+		t.Log(info.Size(), len(original))
+		t.Fail()
+	}
+}
+
+func TestLogSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	c := LogConfig{SegmentSize: 200, SnapshotInterval: 3}
+	l := openLog(t, c, dir)
+	fill(t, l)
+	want := contents(t, l)
+
+	// Snapshots are written automatically and the log is compacted behind them.
+	segments, snapshots, _ := l.list()
+	if len(snapshots) != 1 || snapshots[0] != 6 || segments[0] != 7 {
+		t.Log(segments, snapshots)
+		t.Fail()
+	}
+	l.Close()
+
+	l = openLog(t, c, dir)
+	if got := contents(t, l); !reflect.DeepEqual(got, want) {
+		t.Log(got, want)
+		t.Fail()
+	}
+
+	// A crash between writing a snapshot and compacting leaves entries that are already in the snapshot, which are not applied again.
+	if err := l.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	l.Close()
+	l = openLog(t, c, dir)
+	defer l.Close()
+	if got := contents(t, l); !reflect.DeepEqual(got, want) {
+		t.Log(got, want)
+		t.Fail()
+	}
+	if err := l.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if segments, snapshots, _ := l.list(); len(snapshots) != 1 || snapshots[0] != 8 || len(segments) != 1 {
+		t.Log(segments, snapshots)
+		t.Fail()
+	}
+}
+
+func TestLogVoidAndAmend(t *testing.T) {
+	dir := t.TempDir()
+	c := LogConfig{SnapshotInterval: 9}
+	l := openLog(t, c, dir)
+	fill(t, l)
+	if _, _, err := l.Record(Match{ID: "5", Player: "b", Opponent: "c", Score: 1}); err != nil {
+		t.Fatal(err)
+	}
+	before := contents(t, l)
+	outcomes, err := l.Void("4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b"} {
+		got, _ := l.Load(id)
+		exp := recalculated(got)
+		if len(got.Glicko2.History) != len(before[id].Glicko2.History)-1 || got.Glicko2.Rating != exp.Rating || outcomes[id].Rating != got.Glicko2.Rating {
+			t.Log(id, got.Glicko2, exp, outcomes[id])
+			t.Fail()
+		}
+	}
+	if got, _ := l.Load("c"); !reflect.DeepEqual(got, before["c"]) {
+		t.Log(got, before["c"])
+		t.Fail()
+	}
+	if _, err := l.Void("4"); err != ErrMatchNotFound {
+		t.Log(err)
+		t.Fail()
+	}
+
+	// Corrections survive a restart, and the remaining matches can still be found, whether from the log or from a snapshot.
+	l.Close()
+	l = openLog(t, c, dir)
+	if _, err := l.Amend("5", "a", 1); err != ErrMatchNotFound {
+		t.Log(err)
+		t.Fail()
+	}
+	amended, err := l.Amend("5", "c", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amended["b"].RatingDelta >= 0 || amended["c"].RatingDelta <= 0 {
+		t.Log(amended)
+		t.Fail()
+	}
+	if err := l.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	want := contents(t, l)
+	l.Close()
+	l = openLog(t, c, dir)
+	defer l.Close()
+	if got := contents(t, l); !reflect.DeepEqual(got, want) {
+		t.Log(got, want)
+		t.Fail()
+	}
+	if _, err := l.Amend("5", "b", 1); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := l.Load("b"); b.Glicko2.History[0].Score != 1 {
+		t.Log(b.Glicko2.History)
+		t.Fail()
+	}
+
+	// Matches in closed periods cannot be corrected in place, and a voided match ID can be recorded again.
+	if _, err := l.Void("1"); err != ErrClosedPeriod {
+		t.Log(err)
+		t.Fail()
+	}
+	if _, _, err := l.Record(Match{ID: "4", Player: "a", Opponent: "c", Score: 1}); err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+)
+
+var (
+	// ErrSystemMismatch is returned when a match is recorded between players rated by different systems.
+	ErrSystemMismatch = errors.New("store: players are rated by different systems")
+
+	// ErrDuplicateMatch is returned when a match is recorded with the ID of a match that has already been recorded, so that a retried request cannot rate the same result twice.
+	ErrDuplicateMatch = errors.New("store: match already recorded")
+
+	// ErrSelfMatch is returned when a match is recorded between a player and themselves.
+	ErrSelfMatch = errors.New("store: player cannot play themselves")
+
+	// ErrMatchNotFound is returned when a match to be voided or amended has not been recorded.
+	ErrMatchNotFound = errors.New("store: match not found")
+
+	// ErrClosedPeriod is returned when a match to be voided or amended was played in a rating period that has since been closed.
+	ErrClosedPeriod = errors.New("store: match is in a closed rating period")
+)
+
+// Match is a result between two stored players, identified by an ID that is unique across all matches. Score and Advantage have the same meaning as in rating.Game.
+type Match struct {
+	ID               string
+	Player, Opponent string
+	Score, Advantage float64
+}
+
+// play rates a match between two players, each against the other's rating from before the match, and returns the Outcome for each.
+func play(a, b Record, m Match) (rating.Outcome, rating.Outcome, error) {
+	if a.ID == b.ID {
+		return rating.Outcome{}, rating.Outcome{}, ErrSelfMatch
+	}
+	switch {
+	case a.Elo != nil && b.Elo != nil:
+		ar, br := a.Elo.Rating, b.Elo.Rating
+		return eloOutcome(a.Elo.Play(br, m.Score, m.Advantage)), eloOutcome(b.Elo.Play(ar, 1-m.Score, -m.Advantage)), nil
+	case a.Glicko != nil && b.Glicko != nil:
+		ar, ad, br, bd := a.Glicko.Rating, a.Glicko.Deviation, b.Glicko.Rating, b.Glicko.Deviation
+		return glickoOutcome(a.Glicko.Play(br, bd, m.Score, m.Advantage)), glickoOutcome(b.Glicko.Play(ar, ad, 1-m.Score, -m.Advantage)), nil
+	case a.Glicko2 != nil && b.Glicko2 != nil:
+		ar, ad, br, bd := a.Glicko2.Rating, a.Glicko2.Deviation, b.Glicko2.Rating, b.Glicko2.Deviation
+		return glicko2Outcome(a.Glicko2.Play(br, bd, m.Score, m.Advantage)), glicko2Outcome(b.Glicko2.Play(ar, ad, 1-m.Score, -m.Advantage)), nil
+	}
+	return rating.Outcome{}, rating.Outcome{}, ErrSystemMismatch
+}
+
+// newPeriod calls NewPeriod on the stored player.
+func (r Record) newPeriod() {
+	switch {
+	case r.Elo != nil:
+		r.Elo.NewPeriod()
+	case r.Glicko != nil:
+		r.Glicko.NewPeriod()
+	case r.Glicko2 != nil:
+		r.Glicko2.NewPeriod()
+	}
+}
+
+// length returns the number of results in the stored player's History.
+func (r Record) length() int {
+	switch {
+	case r.Elo != nil:
+		return len(r.Elo.History)
+	case r.Glicko != nil:
+		return len(r.Glicko.History)
+	case r.Glicko2 != nil:
+		return len(r.Glicko2.History)
+	}
+	return 0
+}
+
+// void calls Void on the stored player.
+func (r Record) void(i int) (rating.Outcome, error) {
+	switch {
+	case r.Elo != nil:
+		o, err := r.Elo.Void(i)
+		return eloOutcome(&o), err
+	case r.Glicko != nil:
+		o, err := r.Glicko.Void(i)
+		return glickoOutcome(o), err
+	}
+	o, err := r.Glicko2.Void(i)
+	return glicko2Outcome(o), err
+}
+
+// amend calls Amend on the stored player.
+func (r Record) amend(i int, score float64) (rating.Outcome, error) {
+	switch {
+	case r.Elo != nil:
+		o, err := r.Elo.Amend(i, score)
+		return eloOutcome(&o), err
+	case r.Glicko != nil:
+		o, err := r.Glicko.Amend(i, score)
+		return glickoOutcome(o), err
+	}
+	o, err := r.Glicko2.Amend(i, score)
+	return glicko2Outcome(o), err
+}
+
+func eloOutcome(o *elo.Outcome) rating.Outcome {
+	return rating.Outcome{Rating: o.Rating, RatingDelta: o.RatingDelta}
+}
+
+func glickoOutcome(o glicko.Outcome) rating.Outcome {
+	return rating.Outcome{Rating: o.Rating, RatingDelta: o.RatingDelta, Deviation: o.Deviation, DeviationDelta: o.DeviationDelta}
+}
+
+func glicko2Outcome(o glicko2.Outcome) rating.Outcome {
+	return rating.Outcome{Rating: o.Rating, RatingDelta: o.RatingDelta, Deviation: o.Deviation, DeviationDelta: o.DeviationDelta, Volatility: o.Volatility, VolatilityDelta: o.VolatilityDelta}
+}
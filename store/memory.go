@@ -0,0 +1,64 @@
+package store
+
+import (
+	"sort"
+	"sync"
+)
+
+// Memory is a Store that keeps players in memory. It is safe for concurrent use, and is useful for tests and for applications that persist players some other way.
+type Memory struct {
+	mu      sync.Mutex
+	records map[string][]byte
+}
+
+// NewMemory returns an empty Memory store.
+func NewMemory() *Memory {
+	return &Memory{records: make(map[string][]byte)}
+}
+
+// Load returns the player stored under the ID.
+func (m *Memory) Load(id string) (Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.records[id]
+	if !ok {
+		return Record{}, ErrNotFound
+	}
+	return decode(data)
+}
+
+// Save stores the Record if its Version matches the stored Version.
+func (m *Memory) Save(r Record) (Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current := 0
+	if data, ok := m.records[r.ID]; ok {
+		stored, err := decode(data)
+		if err != nil {
+			return Record{}, err
+		}
+		current = stored.Version
+	}
+	if r.Version != current {
+		return Record{}, ErrConflict
+	}
+	r.Version++
+	data, err := encode(r)
+	if err != nil {
+		return Record{}, err
+	}
+	m.records[r.ID] = data
+	return decode(data)
+}
+
+// IDs returns the IDs of every stored player in ascending order.
+func (m *Memory) IDs() ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.records))
+	for id := range m.records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
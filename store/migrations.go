@@ -0,0 +1,52 @@
+package store
+
+// migrations holds the schema used by SQL, in order. Migration i+1 is applied to a database at schema version i. New migrations must only ever be appended, so that existing databases can be upgraded.
+var migrations = []string{
+	`CREATE TABLE rating_players (
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		system VARCHAR(16) NOT NULL,
+		version BIGINT NOT NULL,
+		period BIGINT NOT NULL,
+		rating DOUBLE PRECISION NOT NULL,
+		deviation DOUBLE PRECISION NOT NULL,
+		volatility DOUBLE PRECISION NOT NULL,
+		games_played BIGINT NOT NULL,
+		peak_rating DOUBLE PRECISION NOT NULL,
+		config TEXT NOT NULL
+	)`,
+	`CREATE TABLE rating_parameters (
+		player_id VARCHAR(255) NOT NULL PRIMARY KEY REFERENCES rating_players (id),
+		initial_rating DOUBLE PRECISION NOT NULL,
+		initial_deviation DOUBLE PRECISION NOT NULL,
+		initial_volatility DOUBLE PRECISION NOT NULL,
+		initial_games_played BIGINT NOT NULL,
+		initial_peak_rating DOUBLE PRECISION NOT NULL
+	)`,
+	`CREATE TABLE rating_history (
+		player_id VARCHAR(255) NOT NULL REFERENCES rating_players (id),
+		period BIGINT NOT NULL,
+		position BIGINT NOT NULL,
+		match_id VARCHAR(255) NOT NULL,
+		rating DOUBLE PRECISION NOT NULL,
+		deviation DOUBLE PRECISION NOT NULL,
+		g DOUBLE PRECISION NOT NULL,
+		e DOUBLE PRECISION NOT NULL,
+		score DOUBLE PRECISION NOT NULL,
+		advantage DOUBLE PRECISION NOT NULL,
+		margin DOUBLE PRECISION NOT NULL,
+		PRIMARY KEY (player_id, period, position)
+	)`,
+	`CREATE TABLE rating_outcomes (
+		match_id VARCHAR(255) NOT NULL,
+		player_id VARCHAR(255) NOT NULL REFERENCES rating_players (id),
+		rating DOUBLE PRECISION NOT NULL,
+		rating_delta DOUBLE PRECISION NOT NULL,
+		deviation DOUBLE PRECISION NOT NULL,
+		deviation_delta DOUBLE PRECISION NOT NULL,
+		volatility DOUBLE PRECISION NOT NULL,
+		volatility_delta DOUBLE PRECISION NOT NULL,
+		PRIMARY KEY (match_id, player_id)
+	)`,
+	`CREATE INDEX rating_outcomes_player ON rating_outcomes (player_id)`,
+	`ALTER TABLE rating_history ADD COLUMN method BIGINT NOT NULL DEFAULT 0`,
+}
@@ -0,0 +1,572 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+)
+
+// ErrPeriodRollover is returned by SQL.Save when the Record no longer continues the player's stored rating period, as happens when NewPeriod has been called on the loaded player. Saving it would discard the period's History rows without moving the player to a new period, so periods must be closed with SQL.NewPeriod, and recorded results corrected with SQL.Void and SQL.Amend.
+var ErrPeriodRollover = errors.New("store: record starts a new rating period; use SQL.NewPeriod")
+
+// Placeholder selects the syntax used for query parameters, which differs between databases.
+type Placeholder int
+
+const (
+
+	// Question uses ? for every parameter, as SQLite and MySQL do.
+	Question Placeholder = iota
+
+	// Dollar numbers the parameters $1, $2, and so on, as PostgreSQL does.
+	Dollar
+)
+
+// SQL is a Store backed by a database/sql database. Each player is stored as a row of its current state and its Parameters, along with a row for each Result in its History. History rows are kept for every rating period, so that earlier periods remain available after NewPeriod, and the Outcome of every match recorded with Record is kept as well. The schema is created and upgraded by Migrate, which must be called before the store is used. SQL uses only portable SQL and works with any driver, but is tested against SQLite.
+type SQL struct {
+	db          *sql.DB
+	placeholder Placeholder
+}
+
+// NewSQL returns an SQL store that uses the database with the given placeholder syntax.
+func NewSQL(db *sql.DB, placeholder Placeholder) *SQL {
+	return &SQL{db: db, placeholder: placeholder}
+}
+
+// Migrate brings the database schema up to date, applying each missing migration in its own transaction. It is safe to call every time the application starts.
+func (s *SQL) Migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS rating_schema_migrations (version BIGINT NOT NULL PRIMARY KEY)`); err != nil {
+		return err
+	}
+	version, err := s.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	for i := version; i < len(migrations); i++ {
+		err := s.transact(func(tx *sql.Tx) error {
+			if _, err := tx.Exec(migrations[i]); err != nil {
+				return fmt.Errorf("store: migration %d: %v", i+1, err)
+			}
+			_, err := tx.Exec(s.rebind(`INSERT INTO rating_schema_migrations (version) VALUES (?)`), i+1)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the number of migrations that have been applied to the database.
+func (s *SQL) SchemaVersion() (int, error) {
+	var version sql.NullInt64
+	if err := s.db.QueryRow(`SELECT MAX(version) FROM rating_schema_migrations`).Scan(&version); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+// Load returns the player stored under the ID, with the History of their current rating period.
+func (s *SQL) Load(id string) (Record, error) {
+	var r Record
+	err := s.transact(func(tx *sql.Tx) error {
+		var err error
+		r, _, err = s.load(tx, id)
+		return err
+	})
+	return r, err
+}
+
+// Save stores the Record if its Version matches the stored Version. The History rows of the player's current rating period are replaced with the Record's History, which must continue the stored period: Save returns ErrPeriodRollover if the Record's Parameters differ from the stored ones or its History does not start with the stored History.
+func (s *SQL) Save(r Record) (Record, error) {
+	err := s.transact(func(tx *sql.Tx) error {
+		stored, _, err := s.load(tx, r.ID)
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+		if err == nil && stored.Version == r.Version {
+			before, err := toRow(stored)
+			if err != nil {
+				return err
+			}
+			after, err := toRow(r)
+			if err != nil {
+				return err
+			}
+			if !continues(before, after) {
+				return ErrPeriodRollover
+			}
+		}
+		r, err = s.save(tx, r, nil)
+		return err
+	})
+	if err != nil {
+		return Record{}, err
+	}
+	return r, nil
+}
+
+// IDs returns the IDs of every stored player in ascending order.
+func (s *SQL) IDs() ([]string, error) {
+	var ids []string
+	err := s.transact(func(tx *sql.Tx) error {
+		var err error
+		ids, err = s.ids(tx)
+		return err
+	})
+	return ids, err
+}
+
+func (s *SQL) ids(tx *sql.Tx) ([]string, error) {
+	rows, err := tx.Query(`SELECT id FROM rating_players ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Record rates a match between two stored players and saves both of them in a single transaction, so that either both players are updated or neither is. Each player is rated against the other's rating from before the match using Play, and the Outcome for each is stored under the match ID and returned. The players must be rated by the same system, and a match ID can only be recorded once.
+func (s *SQL) Record(m Match) (rating.Outcome, rating.Outcome, error) {
+	var outcomes [2]rating.Outcome
+	err := s.transact(func(tx *sql.Tx) error {
+		a, aPeriod, err := s.load(tx, m.Player)
+		if err != nil {
+			return err
+		}
+		b, bPeriod, err := s.load(tx, m.Opponent)
+		if err != nil {
+			return err
+		}
+		var n int
+		if err := tx.QueryRow(s.rebind(`SELECT COUNT(*) FROM rating_outcomes WHERE match_id = ?`), m.ID).Scan(&n); err != nil {
+			return err
+		}
+		if n > 0 {
+			return ErrDuplicateMatch
+		}
+		outcomes[0], outcomes[1], err = play(a, b, m)
+		if err != nil {
+			return err
+		}
+		for i, r := range []Record{a, b} {
+			period := []int{aPeriod, bPeriod}[i]
+			matches, err := s.matchIDs(tx, r.ID, period)
+			if err != nil {
+				return err
+			}
+			matches[r.length()-1] = m.ID
+			if _, err := s.save(tx, r, matches); err != nil {
+				return err
+			}
+			if err := s.insertOutcome(tx, m.ID, r.ID, outcomes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return outcomes[0], outcomes[1], err
+}
+
+// Outcomes returns the Outcome of a recorded match for each of its players, keyed by player ID.
+func (s *SQL) Outcomes(matchID string) (map[string]rating.Outcome, error) {
+	rows, err := s.db.Query(s.rebind(`SELECT player_id, rating, rating_delta, deviation, deviation_delta, volatility, volatility_delta FROM rating_outcomes WHERE match_id = ?`), matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	outcomes := make(map[string]rating.Outcome)
+	for rows.Next() {
+		var id string
+		var o rating.Outcome
+		if err := rows.Scan(&id, &o.Rating, &o.RatingDelta, &o.Deviation, &o.DeviationDelta, &o.Volatility, &o.VolatilityDelta); err != nil {
+			return nil, err
+		}
+		outcomes[id] = o
+	}
+	return outcomes, rows.Err()
+}
+
+// Void removes a recorded match from the History of both of its players and recalculates their ratings for the current rating period, in a single transaction. The match's stored Outcomes are deleted, and the change in each player's state is returned, keyed by player ID. Void returns ErrMatchNotFound if no match is recorded under the ID, and ErrClosedPeriod if the match was played in an earlier rating period, whose ratings can only be corrected by replaying the league's history.
+func (s *SQL) Void(matchID string) (map[string]rating.Outcome, error) {
+	return s.correct(matchID, "", true, func(r Record, i int) (rating.Outcome, error) {
+		return r.void(i)
+	})
+}
+
+// Amend replaces the result of a recorded match and recalculates the ratings of both of its players for the current rating period, in a single transaction. The score is the result for the player with the given ID, and their opponent receives the complementary score. The match's stored Outcomes are replaced with the change caused by the amendment, which is also returned, keyed by player ID. Amend returns the same errors as Void, and ErrMatchNotFound if the player did not play in the match.
+func (s *SQL) Amend(matchID, playerID string, score float64) (map[string]rating.Outcome, error) {
+	return s.correct(matchID, playerID, false, func(r Record, i int) (rating.Outcome, error) {
+		if r.ID == playerID {
+			return r.amend(i, score)
+		}
+		return r.amend(i, 1-score)
+	})
+}
+
+// correct applies a change to the result of a recorded match for each of its players, and removes the match from their History if void is set. If playerID is set, the match must have been played by that player.
+func (s *SQL) correct(matchID, playerID string, void bool, change func(r Record, i int) (rating.Outcome, error)) (map[string]rating.Outcome, error) {
+	outcomes := make(map[string]rating.Outcome)
+	err := s.transact(func(tx *sql.Tx) error {
+		rows, err := tx.Query(s.rebind(`SELECT player_id, period, position FROM rating_history WHERE match_id = ? ORDER BY player_id`), matchID)
+		if err != nil {
+			return err
+		}
+		var players []string
+		var periods, positions []int
+		for rows.Next() {
+			var player string
+			var period, position int
+			if err := rows.Scan(&player, &period, &position); err != nil {
+				rows.Close()
+				return err
+			}
+			players, periods, positions = append(players, player), append(periods, period), append(positions, position)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(players) == 0 || matchID == "" {
+			return ErrMatchNotFound
+		}
+		if playerID != "" && playerID != players[0] && playerID != players[len(players)-1] {
+			return ErrMatchNotFound
+		}
+		if _, err := tx.Exec(s.rebind(`DELETE FROM rating_outcomes WHERE match_id = ?`), matchID); err != nil {
+			return err
+		}
+		for i, id := range players {
+			r, period, err := s.load(tx, id)
+			if err != nil {
+				return err
+			}
+			if periods[i] != period {
+				return ErrClosedPeriod
+			}
+			matches, err := s.matchIDs(tx, id, period)
+			if err != nil {
+				return err
+			}
+			o, err := change(r, positions[i])
+			if err != nil {
+				return err
+			}
+			if void {
+				matches = without(matches, positions[i])
+			}
+			if _, err := s.save(tx, r, matches); err != nil {
+				return err
+			}
+			if !void {
+				if err := s.insertOutcome(tx, matchID, id, o); err != nil {
+					return err
+				}
+			}
+			outcomes[id] = o
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcomes, nil
+}
+
+// NewPeriod calls NewPeriod on every stored player and saves them in a single transaction. The History rows of the closed period are kept, and each player starts the new period with an empty History.
+func (s *SQL) NewPeriod() error {
+	return s.transact(func(tx *sql.Tx) error {
+		ids, err := s.ids(tx)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			r, period, err := s.load(tx, id)
+			if err != nil {
+				return err
+			}
+			r.newPeriod()
+			if _, err := tx.Exec(s.rebind(`UPDATE rating_players SET period = ? WHERE id = ?`), period+1, id); err != nil {
+				return err
+			}
+			if _, err := s.save(tx, r, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// row holds the columns shared by the players of every system. Fields that a system does not use are stored as zero.
+type row struct {
+	system                                    string
+	rating, deviation, volatility, peakRating float64
+	gamesPlayed                               int
+	config                                    interface{}
+	initialRating, initialDeviation           float64
+	initialVolatility, initialPeakRating      float64
+	initialGamesPlayed                        int
+	history                                   []historyRow
+}
+
+type historyRow struct {
+	rating, deviation, g, e, score, advantage, margin float64
+	method                                            int
+}
+
+func toRow(r Record) (row, error) {
+	switch {
+	case r.Elo != nil:
+		p := r.Elo
+		w := row{system: "elo", rating: p.Rating, peakRating: p.PeakRating, gamesPlayed: p.GamesPlayed, config: p.Config,
+			initialRating: p.Parameters.InitialRating, initialPeakRating: p.Parameters.InitialPeakRating, initialGamesPlayed: p.Parameters.InitialGamesPlayed}
+		for _, h := range p.History {
+			w.history = append(w.history, historyRow{rating: h.Rating, score: h.Score, margin: h.Margin, advantage: h.Advantage, method: int(h.Method)})
+		}
+		return w, nil
+	case r.Glicko != nil:
+		p := r.Glicko
+		w := row{system: "glicko", rating: p.Rating, deviation: p.Deviation, config: p.Config,
+			initialRating: p.Parameters.InitialRating, initialDeviation: p.Parameters.InitialDeviation}
+		for _, h := range p.History {
+			w.history = append(w.history, historyRow{rating: h.Rating, deviation: h.Deviation, g: h.G, e: h.E, score: h.Score, advantage: h.Advantage})
+		}
+		return w, nil
+	case r.Glicko2 != nil:
+		p := r.Glicko2
+		w := row{system: "glicko2", rating: p.Rating, deviation: p.Deviation, volatility: p.Volatility, config: p.Config,
+			initialRating: p.Parameters.InitialRating, initialDeviation: p.Parameters.InitialDeviation, initialVolatility: p.Parameters.InitialVolatility}
+		for _, h := range p.History {
+			w.history = append(w.history, historyRow{rating: h.Rating, deviation: h.Deviation, g: h.G, e: h.E, score: h.Score, advantage: h.Advantage})
+		}
+		return w, nil
+	}
+	return row{}, fmt.Errorf("store: record %q has no player", r.ID)
+}
+
+func (w row) record(id string, version int, config []byte) (Record, error) {
+	r := Record{ID: id, Version: version}
+	switch w.system {
+	case "elo":
+		p := &elo.Player{Rating: w.rating, GamesPlayed: w.gamesPlayed, PeakRating: w.peakRating,
+			Parameters: elo.Parameters{InitialRating: w.initialRating, InitialGamesPlayed: w.initialGamesPlayed, InitialPeakRating: w.initialPeakRating}}
+		for _, h := range w.history {
+			p.History = append(p.History, elo.Result{Rating: h.rating, Score: h.score, Margin: h.margin, Advantage: h.advantage, Method: elo.Method(h.method)})
+		}
+		r.Elo = p
+		return r, json.Unmarshal(config, &p.Config)
+	case "glicko":
+		p := &glicko.Player{Rating: w.rating, Deviation: w.deviation,
+			Parameters: glicko.Parameters{InitialRating: w.initialRating, InitialDeviation: w.initialDeviation}}
+		for _, h := range w.history {
+			p.History = append(p.History, glicko.Result{Rating: h.rating, Deviation: h.deviation, G: h.g, E: h.e, Score: h.score, Advantage: h.advantage})
+		}
+		r.Glicko = p
+		return r, json.Unmarshal(config, &p.Config)
+	case "glicko2":
+		p := &glicko2.Player{Rating: w.rating, Deviation: w.deviation, Volatility: w.volatility,
+			Parameters: glicko2.Parameters{InitialRating: w.initialRating, InitialDeviation: w.initialDeviation, InitialVolatility: w.initialVolatility}}
+		for _, h := range w.history {
+			p.History = append(p.History, glicko2.Result{Rating: h.rating, Deviation: h.deviation, G: h.g, E: h.e, Score: h.score, Advantage: h.advantage})
+		}
+		r.Glicko2 = p
+		return r, json.Unmarshal(config, &p.Config)
+	}
+	return Record{}, fmt.Errorf("store: player %q has unknown system %q", id, w.system)
+}
+
+// load reads a player and the History of their current rating period, and returns the period.
+func (s *SQL) load(tx *sql.Tx, id string) (Record, int, error) {
+	var w row
+	var version, period int
+	var config []byte
+	err := tx.QueryRow(s.rebind(`SELECT p.system, p.version, p.period, p.rating, p.deviation, p.volatility, p.games_played, p.peak_rating, p.config,
+		m.initial_rating, m.initial_deviation, m.initial_volatility, m.initial_games_played, m.initial_peak_rating
+		FROM rating_players p JOIN rating_parameters m ON m.player_id = p.id WHERE p.id = ?`), id).Scan(
+		&w.system, &version, &period, &w.rating, &w.deviation, &w.volatility, &w.gamesPlayed, &w.peakRating, &config,
+		&w.initialRating, &w.initialDeviation, &w.initialVolatility, &w.initialGamesPlayed, &w.initialPeakRating)
+	if err == sql.ErrNoRows {
+		return Record{}, 0, ErrNotFound
+	}
+	if err != nil {
+		return Record{}, 0, err
+	}
+	rows, err := tx.Query(s.rebind(`SELECT rating, deviation, g, e, score, advantage, margin, method FROM rating_history WHERE player_id = ? AND period = ? ORDER BY position`), id, period)
+	if err != nil {
+		return Record{}, 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var h historyRow
+		if err := rows.Scan(&h.rating, &h.deviation, &h.g, &h.e, &h.score, &h.advantage, &h.margin, &h.method); err != nil {
+			return Record{}, 0, err
+		}
+		w.history = append(w.history, h)
+	}
+	if err := rows.Err(); err != nil {
+		return Record{}, 0, err
+	}
+	r, err := w.record(id, version, config)
+	return r, period, err
+}
+
+// save writes a player if its Version matches, and returns it with the new Version. The match IDs of the player's History rows are taken from matches by position, or kept as they are if matches is nil.
+func (s *SQL) save(tx *sql.Tx, r Record, matches map[int]string) (Record, error) {
+	w, err := toRow(r)
+	if err != nil {
+		return Record{}, err
+	}
+	config, err := json.Marshal(w.config)
+	if err != nil {
+		return Record{}, err
+	}
+
+	var current, period int
+	err = tx.QueryRow(s.rebind(`SELECT version, period FROM rating_players WHERE id = ?`), r.ID).Scan(&current, &period)
+	if err != nil && err != sql.ErrNoRows {
+		return Record{}, err
+	}
+	if r.Version != current {
+		return Record{}, ErrConflict
+	}
+	if current == 0 {
+		_, err = tx.Exec(s.rebind(`INSERT INTO rating_players (id, system, version, period, rating, deviation, volatility, games_played, peak_rating, config) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			r.ID, w.system, 1, 0, w.rating, w.deviation, w.volatility, w.gamesPlayed, w.peakRating, string(config))
+		if err != nil {
+			return Record{}, err
+		}
+	} else {
+		result, err := tx.Exec(s.rebind(`UPDATE rating_players SET system = ?, version = ?, rating = ?, deviation = ?, volatility = ?, games_played = ?, peak_rating = ?, config = ? WHERE id = ? AND version = ?`),
+			w.system, current+1, w.rating, w.deviation, w.volatility, w.gamesPlayed, w.peakRating, string(config), r.ID, current)
+		if err != nil {
+			return Record{}, err
+		}
+		if n, err := result.RowsAffected(); err != nil || n != 1 {
+			return Record{}, ErrConflict
+		}
+	}
+
+	if _, err := tx.Exec(s.rebind(`DELETE FROM rating_parameters WHERE player_id = ?`), r.ID); err != nil {
+		return Record{}, err
+	}
+	_, err = tx.Exec(s.rebind(`INSERT INTO rating_parameters (player_id, initial_rating, initial_deviation, initial_volatility, initial_games_played, initial_peak_rating) VALUES (?, ?, ?, ?, ?, ?)`),
+		r.ID, w.initialRating, w.initialDeviation, w.initialVolatility, w.initialGamesPlayed, w.initialPeakRating)
+	if err != nil {
+		return Record{}, err
+	}
+
+	if matches == nil {
+		matches, err = s.matchIDs(tx, r.ID, period)
+		if err != nil {
+			return Record{}, err
+		}
+	}
+	if _, err := tx.Exec(s.rebind(`DELETE FROM rating_history WHERE player_id = ? AND period = ?`), r.ID, period); err != nil {
+		return Record{}, err
+	}
+	for i, h := range w.history {
+		_, err := tx.Exec(s.rebind(`INSERT INTO rating_history (player_id, period, position, match_id, rating, deviation, g, e, score, advantage, margin, method) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			r.ID, period, i, matches[i], h.rating, h.deviation, h.g, h.e, h.score, h.advantage, h.margin, h.method)
+		if err != nil {
+			return Record{}, err
+		}
+	}
+	r.Version = current + 1
+	return r, nil
+}
+
+func (s *SQL) matchIDs(tx *sql.Tx, id string, period int) (map[int]string, error) {
+	rows, err := tx.Query(s.rebind(`SELECT position, match_id FROM rating_history WHERE player_id = ? AND period = ?`), id, period)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	matches := make(map[int]string)
+	for rows.Next() {
+		var position int
+		var match string
+		if err := rows.Scan(&position, &match); err != nil {
+			return nil, err
+		}
+		matches[position] = match
+	}
+	return matches, rows.Err()
+}
+
+// continues reports whether a player's state carries on the rating period stored in the stored row, which is the case if it has the same Parameters and its History starts with the stored History.
+func continues(stored, w row) bool {
+	if stored.system != w.system || stored.initialRating != w.initialRating || stored.initialDeviation != w.initialDeviation || stored.initialVolatility != w.initialVolatility ||
+		stored.initialPeakRating != w.initialPeakRating || stored.initialGamesPlayed != w.initialGamesPlayed || len(w.history) < len(stored.history) {
+		return false
+	}
+	for i, h := range stored.history {
+		if w.history[i] != h {
+			return false
+		}
+	}
+	return true
+}
+
+// without returns the match IDs of a History after the result at position i has been removed.
+func without(matches map[int]string, i int) map[int]string {
+	shifted := make(map[int]string, len(matches))
+	for position, id := range matches {
+		if position > i {
+			shifted[position-1] = id
+		} else if position < i {
+			shifted[position] = id
+		}
+	}
+	return shifted
+}
+
+func (s *SQL) insertOutcome(tx *sql.Tx, matchID, playerID string, o rating.Outcome) error {
+	_, err := tx.Exec(s.rebind(`INSERT INTO rating_outcomes (match_id, player_id, rating, rating_delta, deviation, deviation_delta, volatility, volatility_delta) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		matchID, playerID, o.Rating, o.RatingDelta, o.Deviation, o.DeviationDelta, o.Volatility, o.VolatilityDelta)
+	return err
+}
+
+func (s *SQL) transact(f func(tx *sql.Tx) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// rebind rewrites the ? placeholders in a query for the store's Placeholder syntax.
+func (s *SQL) rebind(query string) string {
+	if s.placeholder != Dollar {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
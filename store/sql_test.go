@@ -0,0 +1,259 @@
+package store
+
+import (
+	"database/sql"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+
+	_ "modernc.org/sqlite"
+)
+
+func openSQL(t *testing.T) *SQL {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "rating.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	s := NewSQL(db, Question)
+	if err := s.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSQL(t *testing.T) {
+	testStore(t, openSQL(t))
+}
+
+func TestMigrate(t *testing.T) {
+	s := openSQL(t)
+	if err := s.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := s.SchemaVersion(); err != nil || version != len(migrations) {
+		t.Log(version, err)
+		t.Fail()
+	}
+}
+
+func TestRecord(t *testing.T) {
+	s := openSQL(t)
+	for _, id := range []string{"a", "b"} {
+		if _, err := s.Save(Record{ID: id, Glicko2: glicko2.NewPlayer(glicko2.Parameters{InitialRating: 1500, InitialDeviation: 200, InitialVolatility: 0.06})}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Save(Record{ID: "c", Elo: elo.NewPlayer(elo.Parameters{InitialRating: 1500})}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both players are rated against each other's rating from before the match.
+	a := glicko2.NewPlayer(glicko2.Parameters{InitialRating: 1500, InitialDeviation: 200, InitialVolatility: 0.06})
+	b := glicko2.NewPlayer(glicko2.Parameters{InitialRating: 1500, InitialDeviation: 200, InitialVolatility: 0.06})
+	expA := a.Win(b.Rating, b.Deviation)
+	expB := b.Lose(1500, 200)
+	oa, ob, err := s.Record(Match{ID: "m1", Player: "a", Opponent: "b", Score: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(oa.Rating-expA.Rating) > 1e-9 || math.Abs(ob.Rating-expB.Rating) > 1e-9 || math.Abs(oa.VolatilityDelta-expA.VolatilityDelta) > 1e-12 {
+		t.Log(oa, ob)
+		t.Fail()
+	}
+	ra, _ := s.Load("a")
+	rb, _ := s.Load("b")
+	if ra.Version != 2 || rb.Version != 2 || ra.Glicko2.Rating != oa.Rating || rb.Glicko2.Rating != ob.Rating || len(ra.Glicko2.History) != 1 {
+		t.Log(ra, rb)
+		t.Fail()
+	}
+	outcomes, err := s.Outcomes("m1")
+	if err != nil || outcomes["a"] != oa || outcomes["b"] != ob {
+		t.Log(outcomes, err)
+		t.Fail()
+	}
+
+	// A match that fails part way through changes neither player.
+	if _, _, err := s.Record(Match{ID: "m2", Player: "a", Opponent: "c", Score: 1}); err != ErrSystemMismatch {
+		t.Log(err)
+		t.Fail()
+	}
+	if _, _, err := s.Record(Match{ID: "m3", Player: "a", Opponent: "missing", Score: 1}); err != ErrNotFound {
+		t.Log(err)
+		t.Fail()
+	}
+	if _, _, err := s.Record(Match{ID: "m1", Player: "a", Opponent: "b", Score: 0}); err != ErrDuplicateMatch {
+		t.Log(err)
+		t.Fail()
+	}
+	if again, _ := s.Load("a"); !reflect.DeepEqual(again, ra) {
+		t.Log(again, ra)
+		t.Fail()
+	}
+	if again, _ := s.Load("b"); !reflect.DeepEqual(again, rb) {
+		t.Log(again, rb)
+		t.Fail()
+	}
+}
+
+func TestSQLNewPeriod(t *testing.T) {
+	s := openSQL(t)
+	for _, id := range []string{"a", "b"} {
+		if _, err := s.Save(Record{ID: id, Glicko: glicko.NewPlayer(glicko.Parameters{InitialRating: 1500, InitialDeviation: 200})}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err := s.Record(Match{ID: "m1", Player: "a", Opponent: "b", Score: 0.5, Advantage: 1}); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := s.Load("a")
+
+	// A player whose period was closed by hand cannot be saved over the stored period.
+	rolled, _ := s.Load("a")
+	rolled.Glicko.NewPeriod()
+	if _, err := s.Save(rolled); err != ErrPeriodRollover {
+		t.Log(err)
+		t.Fail()
+	}
+	if got, _ := s.Load("a"); !reflect.DeepEqual(got, before) {
+		t.Log(got, before)
+		t.Fail()
+	}
+
+	// A player whose period is carried on can be saved as usual.
+	played, _ := s.Load("a")
+	played.Glicko.Play(1500, 200, 1, 0)
+	if _, err := s.Save(played); err != nil {
+		t.Fatal(err)
+	}
+	before, _ = s.Load("a")
+	if err := s.NewPeriod(); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := s.Load("a")
+	before.Glicko.NewPeriod()
+	if len(after.Glicko.History) != 0 || !reflect.DeepEqual(after.Glicko.Parameters, before.Glicko.Parameters) {
+		t.Log(after.Glicko)
+		t.Fail()
+	}
+
+	// The closed period's History is kept alongside the new one.
+	if _, _, err := s.Record(Match{ID: "m2", Player: "b", Opponent: "a", Score: 1}); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM rating_history WHERE player_id = 'a'`).Scan(&n); err != nil || n != 3 {
+		t.Log(n, err)
+		t.Fail()
+	}
+}
+
+func TestRebind(t *testing.T) {
+	s := &SQL{placeholder: Dollar}
+	if q := s.rebind(`SELECT a FROM b WHERE c = ? AND d = ?`); q != `SELECT a FROM b WHERE c = $1 AND d = $2` {
+		t.Log(q)
+		t.Fail()
+	}
+}
+
+// recalculated rates a new player through the stored player's History from scratch.
+func recalculated(r Record) *glicko2.Player {
+	p := glicko2.NewPlayer(r.Glicko2.Parameters)
+	for _, h := range r.Glicko2.History {
+		p.Play(h.Rating, h.Deviation, h.Score, 0)
+	}
+	return p
+}
+
+func TestVoidAndAmend(t *testing.T) {
+	s := openSQL(t)
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := s.Save(Record{ID: id, Glicko2: glicko2.NewPlayer(glicko2.Parameters{InitialRating: 1500, InitialDeviation: 200, InitialVolatility: 0.06})}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, m := range []Match{
+		{ID: "m1", Player: "a", Opponent: "b", Score: 1},
+		{ID: "m2", Player: "a", Opponent: "c", Score: 0},
+		{ID: "m3", Player: "b", Opponent: "c", Score: 1},
+	} {
+		if _, _, err := s.Record(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, _ := s.Load("c")
+	outcomes, err := s.Void("m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outcomes) != 2 {
+		t.Log(outcomes)
+		t.Fail()
+	}
+	for _, id := range []string{"a", "b"} {
+		got, _ := s.Load(id)
+		exp := recalculated(got)
+		if len(got.Glicko2.History) != 1 || got.Glicko2.Rating != exp.Rating || got.Glicko2.Deviation != exp.Deviation || outcomes[id].Rating != got.Glicko2.Rating {
+			t.Log(id, got.Glicko2, exp, outcomes[id])
+			t.Fail()
+		}
+	}
+
+	// Only the two players in the match are recalculated.
+	if again, _ := s.Load("c"); !reflect.DeepEqual(again, c) {
+		t.Log(again, c)
+		t.Fail()
+	}
+	if o, _ := s.Outcomes("m1"); len(o) != 0 {
+		t.Log(o)
+		t.Fail()
+	}
+	if _, err := s.Void("m1"); err != ErrMatchNotFound {
+		t.Log(err)
+		t.Fail()
+	}
+
+	// The remaining matches can still be found after the History has shifted.
+	if _, err := s.Amend("m3", "a", 1); err != ErrMatchNotFound {
+		t.Log(err)
+		t.Fail()
+	}
+	amended, err := s.Amend("m3", "c", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := s.Load("b")
+	c, _ = s.Load("c")
+	if b.Glicko2.History[0].Score != 0 || c.Glicko2.History[1].Score != 1 || c.Glicko2.History[0].Score != 1 {
+		t.Log(b.Glicko2.History, c.Glicko2.History)
+		t.Fail()
+	}
+	for _, r := range []Record{b, c} {
+		if exp := recalculated(r); r.Glicko2.Rating != exp.Rating || amended[r.ID].Rating != exp.Rating {
+			t.Log(r.ID, r.Glicko2, exp)
+			t.Fail()
+		}
+	}
+	if amended["b"].RatingDelta >= 0 || amended["c"].RatingDelta <= 0 {
+		t.Log(amended)
+		t.Fail()
+	}
+	if o, _ := s.Outcomes("m3"); !reflect.DeepEqual(o, amended) {
+		t.Log(o, amended)
+		t.Fail()
+	}
+
+	// Matches in closed periods cannot be corrected in place.
+	if err := s.NewPeriod(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Void("m2"); err != ErrClosedPeriod {
+		t.Log(err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,46 @@
+// Package store persists players between runs of an application. A Store loads and saves players by ID, and uses optimistic versioning so that two writers working from the same copy of a player cannot silently overwrite each other's results.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+)
+
+var (
+	// ErrNotFound is returned when no player is stored under an ID.
+	ErrNotFound = errors.New("store: player not found")
+
+	// ErrConflict is returned when a Record is saved with a Version other than the one currently stored, which means another writer has saved the player since it was loaded.
+	ErrConflict = errors.New("store: version conflict")
+)
+
+// Record is a stored player. Exactly one of Elo, Glicko, and Glicko2 should be set. Version is the number of times the player has been saved, and is zero for a player that has never been saved.
+type Record struct {
+	ID      string
+	Version int
+	Elo     *elo.Player     `json:",omitempty"`
+	Glicko  *glicko.Player  `json:",omitempty"`
+	Glicko2 *glicko2.Player `json:",omitempty"`
+}
+
+// Store loads and saves players by ID. Load returns ErrNotFound if no player is stored under the ID. Save stores the Record if its Version matches the stored Version, or is zero for a new player, and returns the Record with its Version incremented; otherwise it returns ErrConflict and leaves the store unchanged. IDs returns the IDs of every stored player in ascending order. The Records passed to and returned by a Store are never shared with its contents, so changing a loaded player has no effect until it is saved.
+type Store interface {
+	Load(id string) (Record, error)
+	Save(r Record) (Record, error)
+	IDs() ([]string, error)
+}
+
+// encode and decode copy a Record through its JSON form, which both detaches it from the caller and checks that every player it holds can be persisted.
+func encode(r Record) ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func decode(data []byte) (Record, error) {
+	var r Record
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
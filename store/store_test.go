@@ -0,0 +1,111 @@
+package store
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dylrich/rating/elo"
+	"github.com/dylrich/rating/glicko"
+	"github.com/dylrich/rating/glicko2"
+)
+
+func players() []Record {
+	ec := elo.DefaultConfig()
+	ec.KFactorPolicy = elo.FIDE{}
+	ec.DrawModel = elo.Davidson{Draw: 0.7}
+	e := ec.NewPlayer(elo.Parameters{InitialRating: 1500})
+	e.Win(1400)
+	e.Score(1450, 3, 1)
+
+	g := glicko.NewPlayer(glicko.Parameters{InitialRating: 1500, InitialDeviation: 200})
+	g.Win(1400, 30)
+	g.Lose(1550, 100)
+
+	g2 := glicko2.NewPlayer(glicko2.Parameters{InitialRating: 1500, InitialDeviation: 200, InitialVolatility: 0.06})
+	g2.Win(1400, 30)
+	g2.Draw(1700, 300)
+	g2.NewPeriod()
+	g2.Lose(1550, 100)
+
+	return []Record{{ID: "elo", Elo: e}, {ID: "glicko", Glicko: g}, {ID: "glicko2", Glicko2: g2}}
+}
+
+func testStore(t *testing.T, s Store) {
+	if _, err := s.Load("elo"); err != ErrNotFound {
+		t.Log(err)
+		t.Fail()
+	}
+	for _, r := range players() {
+		saved, err := s.Save(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := s.Load(r.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Version = 1
+		if saved.Version != 1 || !reflect.DeepEqual(r, loaded) || !reflect.DeepEqual(saved, loaded) {
+			t.Log(r, loaded)
+			t.Fail()
+		}
+	}
+
+	// Two writers load the same version. The first to save wins, and the second must reload.
+	first, _ := s.Load("glicko")
+	second, _ := s.Load("glicko")
+	first.Glicko.Win(1500, 50)
+	if _, err := s.Save(first); err != nil {
+		t.Fatal(err)
+	}
+	second.Glicko.Lose(1500, 50)
+	if _, err := s.Save(second); err != ErrConflict {
+		t.Log(err)
+		t.Fail()
+	}
+	if r, _ := s.Load("glicko"); r.Version != 2 || r.Glicko.Rating != first.Glicko.Rating {
+		t.Log(r)
+		t.Fail()
+	}
+	if _, err := s.Save(Record{ID: "glicko", Glicko: glicko.NewPlayer(glicko.Parameters{})}); err != ErrConflict {
+		t.Log(err)
+		t.Fail()
+	}
+
+	// Changes to a loaded player are not visible until it is saved.
+	r, _ := s.Load("elo")
+	r.Elo.Win(1000)
+	if again, _ := s.Load("elo"); again.Elo.Rating == r.Elo.Rating {
+		t.Fail()
+	}
+
+	// A player that cannot be encoded is rejected without changing the store.
+	c := elo.DefaultConfig()
+	c.KFactorPolicy = elo.KFactorFunc(func(p *elo.Player) float64 { return 10 })
+	if _, err := s.Save(Record{ID: "func", Elo: c.NewPlayer(elo.Parameters{})}); err == nil {
+		t.Fail()
+	}
+
+	ids, err := s.IDs()
+	if err != nil || !reflect.DeepEqual(ids, []string{"elo", "glicko", "glicko2"}) {
+		t.Log(ids, err)
+		t.Fail()
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, NewMemory())
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "players.json")
+	testStore(t, NewFile(path))
+
+	// The players survive being reopened.
+	r, err := NewFile(path).Load("glicko2")
+	if err != nil || r.Version != 1 || !reflect.DeepEqual(r.Glicko2, players()[2].Glicko2) {
+		t.Log(r, err)
+		t.Fail()
+	}
+}
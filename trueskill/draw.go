@@ -0,0 +1,69 @@
+package trueskill
+
+import (
+	"math"
+
+	"github.com/dylrich/rating"
+	"github.com/dylrich/rating/internal/league"
+	"github.com/dylrich/rating/internal/normal"
+	"github.com/dylrich/rating/internal/optimize"
+)
+
+// Probabilities returns the separate chances of a win, a draw, and a loss for a player against an opponent under TrueSkill's draw margin model. The match is drawn when the difference between the two players' performances is smaller than the draw margin implied by Config.DrawProbability.
+func (c Config) Probabilities(rating, deviation, opponentRating, opponentDeviation float64) rating.Probabilities {
+	c = c.withDefaults()
+	margin := c.drawMargin(2)
+	spread := normal.Spread(c.Beta, deviation, opponentDeviation)
+	win := normal.CDF((rating - opponentRating - margin) / spread)
+	loss := normal.CDF((opponentRating - rating - margin) / spread)
+	return probabilities(win, loss)
+}
+
+// Probabilities returns the calling Player's chances of a win, a draw, and a loss against an opponent with the given rating and deviation, using the Player's Config.
+func (p *Player) Probabilities(rating, deviation float64) rating.Probabilities {
+	return p.Config.Probabilities(p.Rating, p.Deviation, rating, deviation)
+}
+
+// FitDrawProbability returns a copy of the Config with DrawProbability chosen to minimise the mean log loss of predicting the win, draw, or loss in each game of a historical dataset. The games must be in chronological order, and are rated one at a time as one-on-one matches, with every player starting from the given Parameters. Each game is predicted from both players' ratings before it was played. The games' scores must be 0, 0.5, or 1.
+func (c Config) FitDrawProbability(p Parameters, games []rating.Game) Config {
+	c = c.withDefaults()
+	c.DrawProbability = optimize.Golden(func(x float64) float64 {
+		trial := c
+		trial.DrawProbability = x
+		return trial.drawLoss(p, games)
+	}, 0.0001, 0.99, 0.0001)
+	return c
+}
+
+func (c Config) drawLoss(p Parameters, games []rating.Game) float64 {
+	if len(games) == 0 {
+		return 0
+	}
+	// Each game is rated on its own, so that the next game is predicted from the ratings after it.
+	sequential := make([]rating.Game, len(games))
+	for i, g := range games {
+		g.Period = i
+		sequential[i] = g
+	}
+	loss := 0.0
+	league.Replay[*Player]{
+		NewPlayer: func() *Player { return c.NewPlayer(p) },
+		Predict: func(a, b *Player, g rating.Game) {
+			loss += a.Probabilities(b.Rating, b.Deviation).LogLoss(g.Score)
+		},
+		RatePeriod: func(_ []*Player, games []rating.Game, players, opponents []*Player) {
+			ranks := []int{1, 1}
+			if games[0].Score > 0.5 {
+				ranks[1] = 2
+			} else if games[0].Score < 0.5 {
+				ranks[0] = 2
+			}
+			Rate([]Team{{Players: []*Player{players[0]}}, {Players: []*Player{opponents[0]}}}, ranks)
+		},
+	}.Run(sequential)
+	return loss / float64(len(games))
+}
+
+func probabilities(win, loss float64) rating.Probabilities {
+	return rating.Probabilities{Win: win, Draw: math.Max(1-win-loss, 0), Loss: loss}
+}
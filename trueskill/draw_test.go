@@ -0,0 +1,56 @@
+package trueskill
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestProbabilities(t *testing.T) {
+	// Between two equal players of known skill, the chance of a draw is the DrawProbability.
+	c := DefaultConfig()
+	p := c.Probabilities(25, 0, 25, 0)
+	if math.Abs(p.Draw-DefaultDrawProbability) > 0.000001 || math.Abs(p.Win-p.Loss) > 0.000001 || math.Abs(p.Win+p.Draw+p.Loss-1) > 0.000001 {
+		t.Log(p)
+		t.Fail()
+	}
+	player := NewPlayer(Parameters{InitialRating: 30})
+	if q := player.Probabilities(25, DefaultInitialDeviation); q.Win <= q.Loss || q != c.Probabilities(30, DefaultInitialDeviation, 25, DefaultInitialDeviation) {
+		t.Log(q)
+		t.Fail()
+	}
+}
+
+func TestFitDrawProbability(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	truth := DefaultConfig()
+	truth.DrawProbability = 0.3
+	margin := truth.drawMargin(2)
+	skills := make([]float64, 20)
+	for i := range skills {
+		skills[i] = 25 + r.NormFloat64()*3
+	}
+	var games []rating.Game
+	for i := 0; i < 4000; i++ {
+		a, b := r.Intn(len(skills)), r.Intn(len(skills))
+		if a == b {
+			continue
+		}
+		difference := skills[a] - skills[b] + r.NormFloat64()*math.Sqrt2*DefaultBeta
+		score := 0.5
+		if difference > margin {
+			score = 1
+		} else if difference < -margin {
+			score = 0
+		}
+		games = append(games, rating.Game{Player: strconv.Itoa(a), Opponent: strconv.Itoa(b), Score: score})
+	}
+	c := DefaultConfig().FitDrawProbability(Parameters{}, games)
+	if math.Abs(c.DrawProbability-0.3) > 0.05 {
+		t.Log(c.DrawProbability)
+		t.Fail()
+	}
+}
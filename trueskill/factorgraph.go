@@ -0,0 +1,171 @@
+package trueskill
+
+import (
+	"math"
+
+	"github.com/dylrich/rating/internal/normal"
+)
+
+// gaussian is a normal distribution in natural parameters: the precision pi = 1 / σ² and the precision adjusted mean tau = μ / σ². Multiplying and dividing distributions in this form is a matter of adding and subtracting the parameters, which is what message passing on the factor graph needs.
+type gaussian struct {
+	pi, tau float64
+}
+
+type variable struct {
+	value gaussian
+}
+
+// edge connects a factor to a variable and holds the most recent message the factor sent along it.
+type edge struct {
+	variable *variable
+	message  gaussian
+}
+
+type priorFactor struct {
+	edge  *edge
+	value gaussian
+}
+
+type likelihoodFactor struct {
+	mean, value *edge
+	variance    float64
+}
+
+type sumFactor struct {
+	sum    *edge
+	terms  []*edge
+	coeffs []float64
+}
+
+type truncateFactor struct {
+	edge       *edge
+	draw       bool
+	drawMargin float64
+}
+
+func newGaussian(mu, sigma float64) gaussian {
+	pi := 1 / math.Pow(sigma, 2)
+	return gaussian{pi: pi, tau: pi * mu}
+}
+
+func (g gaussian) mu() float64 {
+	if g.pi == 0 {
+		return 0
+	}
+	return g.tau / g.pi
+}
+
+func (g gaussian) sigma() float64 {
+	if g.pi == 0 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(1 / g.pi)
+}
+
+func (g gaussian) mul(o gaussian) gaussian {
+	return gaussian{pi: g.pi + o.pi, tau: g.tau + o.tau}
+}
+
+func (g gaussian) div(o gaussian) gaussian {
+	return gaussian{pi: g.pi - o.pi, tau: g.tau - o.tau}
+}
+
+func newEdge(v *variable) *edge {
+	return &edge{variable: v}
+}
+
+// set replaces the variable's value and returns how much it changed, which is used to detect convergence.
+func (v *variable) set(value gaussian) float64 {
+	piDelta := math.Abs(v.value.pi - value.pi)
+	delta := 0.0
+	if !math.IsInf(piDelta, 1) {
+		delta = math.Max(math.Abs(v.value.tau-value.tau), math.Sqrt(piDelta))
+	}
+	v.value = value
+	return delta
+}
+
+// cavity returns the variable's value with this edge's message removed, which is the information the variable holds from every other factor.
+func (e *edge) cavity() gaussian {
+	return e.variable.value.div(e.message)
+}
+
+func (e *edge) updateMessage(message gaussian) float64 {
+	old := e.message
+	e.message = message
+	return e.variable.set(e.variable.value.div(old).mul(message))
+}
+
+func (e *edge) updateValue(value gaussian) float64 {
+	e.message = value.mul(e.message).div(e.variable.value)
+	return e.variable.set(value)
+}
+
+func (f *priorFactor) down() float64 {
+	return f.edge.updateValue(f.value)
+}
+
+func (f *likelihoodFactor) down() float64 {
+	msg := f.mean.cavity()
+	a := 1 / (1 + f.variance*msg.pi)
+	return f.value.updateMessage(gaussian{pi: a * msg.pi, tau: a * msg.tau})
+}
+
+func (f *likelihoodFactor) up() float64 {
+	msg := f.value.cavity()
+	a := 1 / (1 + f.variance*msg.pi)
+	return f.mean.updateMessage(gaussian{pi: a * msg.pi, tau: a * msg.tau})
+}
+
+func (f *sumFactor) down() float64 {
+	return update(f.sum, f.terms, f.coeffs)
+}
+
+// up updates the term at index from the sum and every other term. A term with a coefficient of zero, such as a player who did not take part in a match, is left unchanged.
+func (f *sumFactor) up(index int) float64 {
+	coeff := f.coeffs[index]
+	if coeff == 0 {
+		return 0
+	}
+	coeffs := make([]float64, len(f.coeffs))
+	edges := make([]*edge, len(f.terms))
+	for i, c := range f.coeffs {
+		coeffs[i] = -c / coeff
+		edges[i] = f.terms[i]
+	}
+	coeffs[index] = 1 / coeff
+	edges[index] = f.sum
+	return update(f.terms[index], edges, coeffs)
+}
+
+func update(target *edge, edges []*edge, coeffs []float64) float64 {
+	piInv, mu := 0.0, 0.0
+	for i, e := range edges {
+		cavity := e.cavity()
+		mu += coeffs[i] * cavity.mu()
+		if math.IsInf(piInv, 1) {
+			continue
+		}
+		if cavity.pi == 0 {
+			piInv = math.Inf(1)
+			continue
+		}
+		piInv += math.Pow(coeffs[i], 2) / cavity.pi
+	}
+	pi := 1 / piInv
+	return target.updateMessage(gaussian{pi: pi, tau: pi * mu})
+}
+
+func (f *truncateFactor) up() float64 {
+	cavity := f.edge.cavity()
+	sqrtPi := math.Sqrt(cavity.pi)
+	diff, margin := cavity.tau/sqrtPi, f.drawMargin*sqrtPi
+	var v, w float64
+	if f.draw {
+		v, w = normal.VDraw(diff, margin), normal.WDraw(diff, margin)
+	} else {
+		v, w = normal.VWin(diff, margin), normal.WWin(diff, margin)
+	}
+	denom := 1 - w
+	return f.edge.updateValue(gaussian{pi: cavity.pi / denom, tau: (cavity.tau + sqrtPi*v) / denom})
+}
@@ -0,0 +1,22 @@
+package trueskill
+
+import (
+	"github.com/dylrich/rating/internal/normal"
+)
+
+// Expected returns the probability that a player's performance in a match exceeds their opponent's, which is their expected score when draws are counted as half a win. Both players' deviations and the performance deviation Beta are taken into account.
+func (c Config) Expected(rating, deviation, opponentRating, opponentDeviation float64) float64 {
+	c = c.withDefaults()
+	return normal.Expected(c.Beta, rating, deviation, opponentRating, opponentDeviation)
+}
+
+// Gap returns the rating difference at which the higher rated player has the given expected score, for players with the given deviations. It is the inverse of Expected, so a matchmaker can find the widest gap that still gives the weaker side a target chance of winning. Probabilities below 0.5 give a negative gap.
+func (c Config) Gap(probability, deviation, opponentDeviation float64) float64 {
+	c = c.withDefaults()
+	return normal.Gap(c.Beta, probability, deviation, opponentDeviation)
+}
+
+// Expected returns the calling Player's expected score against an opponent with the given rating and deviation, using the Player's Config.
+func (p *Player) Expected(rating, deviation float64) float64 {
+	return p.Config.Expected(p.Rating, p.Deviation, rating, deviation)
+}
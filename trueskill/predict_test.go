@@ -0,0 +1,47 @@
+package trueskill
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	c := DefaultConfig()
+	if e := c.Expected(25, DefaultInitialDeviation, 25, DefaultInitialDeviation); e != 0.5 {
+		t.Log(e)
+		t.Fail()
+	}
+
+	// After one win between new players, TrueSkill leaves the winner this far ahead.
+	a, b := NewPlayer(Parameters{}), NewPlayer(Parameters{})
+	a.Win(b.Rating, b.Deviation)
+	b.Lose(DefaultInitialRating, DefaultInitialDeviation)
+	if e := a.Expected(b.Rating, b.Deviation); math.Abs(e-0.773231) > 0.000001 || e != c.Expected(a.Rating, a.Deviation, b.Rating, b.Deviation) {
+		t.Log(e)
+		t.Fail()
+	}
+
+	// A larger Beta makes performances noisier and the favourite less certain to win.
+	if e := (Config{Beta: 2 * DefaultBeta}).Expected(a.Rating, a.Deviation, b.Rating, b.Deviation); e >= 0.773231 || e <= 0.5 {
+		t.Log(e)
+		t.Fail()
+	}
+}
+
+func TestGap(t *testing.T) {
+	c := DefaultConfig()
+	a, b := NewPlayer(Parameters{}), NewPlayer(Parameters{})
+	a.Win(b.Rating, b.Deviation)
+	b.Lose(DefaultInitialRating, DefaultInitialDeviation)
+	if g := c.Gap(0.773231, a.Deviation, b.Deviation); math.Abs(g-(a.Rating-b.Rating)) > 0.0001 {
+		t.Log(g, a.Rating-b.Rating)
+		t.Fail()
+	}
+	for _, probability := range []float64{0.1, 0.5, 0.75, 0.99} {
+		gap := c.Gap(probability, 2, 5)
+		if e := c.Expected(25+gap, 2, 25, 5); math.Abs(e-probability) > 0.000001 {
+			t.Log(probability, e)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package trueskill
+
+import (
+	"math"
+)
+
+// Quality returns the probability of a draw between the teams if they were evenly matched, relative to the draw probability of two perfectly equal teams. Values closer to 1 indicate a fairer match, so Quality can be used for matchmaking before a match is played. The Config of the first player is used.
+func Quality(teams []Team) float64 {
+	if len(teams) < 2 {
+		return 0
+	}
+	c := teams[0].Players[0].Config
+	var means, variances, weights []float64
+	var team []int
+	for i, t := range teams {
+		for j, p := range t.Players {
+			means = append(means, p.Rating)
+			variances = append(variances, math.Pow(p.Deviation, 2))
+			weights = append(weights, t.weight(j))
+			team = append(team, i)
+		}
+	}
+
+	// a has a row for each pair of adjacent teams, comparing the weighted performance of the first team with the second.
+	a := make([][]float64, len(teams)-1)
+	for i := range a {
+		a[i] = make([]float64, len(means))
+		for j := range means {
+			if team[j] == i {
+				a[i][j] = weights[j]
+			} else if team[j] == i+1 {
+				a[i][j] = -weights[j]
+			}
+		}
+	}
+
+	n := len(a)
+	ata := make([][]float64, n)
+	middle := make([][]float64, n)
+	end := make([]float64, n)
+	for i := 0; i < n; i++ {
+		ata[i] = make([]float64, n)
+		middle[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			for k := range means {
+				ata[i][j] += math.Pow(c.Beta, 2) * a[i][k] * a[j][k]
+				middle[i][j] += a[i][k] * variances[k] * a[j][k]
+			}
+			middle[i][j] += ata[i][j]
+		}
+		for k := range means {
+			end[i] += a[i][k] * means[k]
+		}
+	}
+
+	x := solve(middle, end)
+	e := 0.0
+	for i := range end {
+		e += end[i] * x[i]
+	}
+	return math.Exp(-0.5*e) * math.Sqrt(determinant(ata)/determinant(middle))
+}
+
+// determinant returns the determinant of a square matrix using Gaussian elimination with partial pivoting.
+func determinant(m [][]float64) float64 {
+	m = clone(m)
+	det := 1.0
+	for col := range m {
+		pivot := col
+		for row := col + 1; row < len(m); row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if m[pivot][col] == 0 {
+			return 0
+		}
+		if pivot != col {
+			m[pivot], m[col] = m[col], m[pivot]
+			det = -det
+		}
+		det *= m[col][col]
+		for row := col + 1; row < len(m); row++ {
+			f := m[row][col] / m[col][col]
+			for k := col; k < len(m); k++ {
+				m[row][k] -= f * m[col][k]
+			}
+		}
+	}
+	return det
+}
+
+// solve returns x such that m·x = b, using Gaussian elimination with partial pivoting. m must be non-singular.
+func solve(m [][]float64, b []float64) []float64 {
+	m = clone(m)
+	x := append([]float64(nil), b...)
+	for col := range m {
+		pivot := col
+		for row := col + 1; row < len(m); row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		m[pivot], m[col] = m[col], m[pivot]
+		x[pivot], x[col] = x[col], x[pivot]
+		for row := col + 1; row < len(m); row++ {
+			f := m[row][col] / m[col][col]
+			for k := col; k < len(m); k++ {
+				m[row][k] -= f * m[col][k]
+			}
+			x[row] -= f * x[col]
+		}
+	}
+	for row := len(m) - 1; row >= 0; row-- {
+		for k := row + 1; k < len(m); k++ {
+			x[row] -= m[row][k] * x[k]
+		}
+		x[row] /= m[row][row]
+	}
+	return x
+}
+
+func clone(m [][]float64) [][]float64 {
+	c := make([][]float64, len(m))
+	for i := range m {
+		c[i] = append([]float64(nil), m[i]...)
+	}
+	return c
+}
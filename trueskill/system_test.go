@@ -0,0 +1,29 @@
+package trueskill
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestSystem(t *testing.T) {
+	var s rating.System = System{}
+	a := s.NewPlayer()
+	outcome := a.Win(rating.Estimate{Rating: DefaultInitialRating, Deviation: DefaultInitialDeviation})
+	if math.Abs(outcome.Rating-29.396) > 0.001 || math.Abs(outcome.Deviation-7.171) > 0.001 {
+		t.Log(outcome)
+		t.Fail()
+	}
+	if a.Estimate() != outcome.Estimate() {
+		t.Log(a.Estimate(), outcome)
+		t.Fail()
+	}
+
+	// A draw between new players gives the published reference value for a 1v1 draw.
+	outcome = s.NewPlayer().Draw(rating.Estimate{Rating: DefaultInitialRating, Deviation: DefaultInitialDeviation})
+	if math.Abs(outcome.Rating-25) > 0.001 || math.Abs(outcome.Deviation-6.458) > 0.001 {
+		t.Log(outcome)
+		t.Fail()
+	}
+}
@@ -0,0 +1,340 @@
+package trueskill
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/dylrich/rating/internal/normal"
+)
+
+const (
+
+	// DefaultInitialDeviation is the standard value for an initial deviation (σ) for players that have no result history.
+	DefaultInitialDeviation = DefaultInitialRating / 3
+
+	// DefaultInitialRating is the standard value for an initial rating (μ) for players that have no result history.
+	DefaultInitialRating = 25.0
+
+	// DefaultBeta is the standard value for Config.Beta.
+	DefaultBeta = DefaultInitialDeviation / 2
+
+	// DefaultDynamic is the standard value for Config.Dynamic.
+	DefaultDynamic = DefaultInitialDeviation / 100
+
+	// DefaultDrawProbability is the standard value for Config.DrawProbability.
+	DefaultDrawProbability = 0.10
+
+	// DefaultConvergenceTolerance is the standard value for Config.ConvergenceTolerance.
+	DefaultConvergenceTolerance = 0.0001
+)
+
+// Config contains the system-wide settings for a league. It is passed to each Player on construction, so leagues with different settings can be rated side by side in the same process. Any zero field is replaced with its package default when the Config is used to create a Player.
+type Config struct {
+
+	// Beta (β) is the deviation of a player's performance in a single match around their true skill. A difference of β in rating corresponds to roughly a 76% chance of winning.
+	Beta float64
+
+	// Dynamic (τ) is added to every player's deviation before each match, so that ratings can keep changing as skill changes over time.
+	Dynamic float64
+
+	// DrawProbability is the chance of a draw between two evenly matched players, which sets the draw margin. Games without draws should use a very small value rather than zero, which is replaced with the default.
+	DrawProbability float64
+
+	// ConvergenceTolerance is the change in the performance differences below which message passing between more than two teams is considered to have converged.
+	ConvergenceTolerance float64
+}
+
+// Player represents an individual participant in the competition. The Player struct contains the Rating (μ) and Deviation (σ) measures which compose the TrueSkill system's estimation of how skilled that player is as well as how reliable that estimation is. These values are moment-in-time snapshots, and will be updated on any new results for that player. The Parameters attribute contains initial values for that player, and should be altered at the beginning of a new rating period with NewPeriod.
+type Player struct {
+	Rating     float64
+	Deviation  float64
+	History    []Result
+	Parameters Parameters
+	Config     Config
+}
+
+// Parameters contains initial values for a player. These are set on instantiation of the player, and can be altered later by using the Player.NewPeriod() method.
+type Parameters struct {
+	InitialDeviation, InitialRating float64
+}
+
+// Result contains the important information from a match that has occurred: the number of teams that took part, how many of them finished ahead of and level with the player's team, the player's partial play weight, and the quality of the match before it was played.
+type Result struct {
+	Teams, Ahead, Tied int
+	Weight, Quality    float64
+}
+
+// Outcome is a snapshot of the current state for a player, including delta values for each Deviation and Rating change. This information can be passed to users to give them an idea of how much the most recent result has impacted their ranking criteria.
+type Outcome struct {
+	Rating, RatingDelta, Deviation, DeviationDelta float64
+}
+
+// Team is a group of players competing together in a match. Weights holds the share of the match each player took part in for partial play, from 0 for a player who did not play at all to 1 for a player who played the whole match. If Weights is nil, every player is given a weight of 1.
+type Team struct {
+	Players []*Player
+	Weights []float64
+}
+
+// DefaultConfig returns a Config populated with the package default values.
+func DefaultConfig() Config {
+	return Config{
+		Beta:                 DefaultBeta,
+		Dynamic:              DefaultDynamic,
+		DrawProbability:      DefaultDrawProbability,
+		ConvergenceTolerance: DefaultConvergenceTolerance,
+	}
+}
+
+// NewPlayer is used to instantiate a new Player object based on the input parameters, using DefaultConfig for the system settings.
+func NewPlayer(p Parameters) *Player {
+	return DefaultConfig().NewPlayer(p)
+}
+
+// NewPlayer is used to instantiate a new Player object that is rated using the calling Config. A zero InitialRating or InitialDeviation is replaced with the package default.
+func (c Config) NewPlayer(p Parameters) *Player {
+	if p.InitialDeviation == 0 {
+		p.InitialDeviation = DefaultInitialDeviation
+	}
+	if p.InitialRating == 0 {
+		p.InitialRating = DefaultInitialRating
+	}
+	return &Player{Rating: p.InitialRating, Deviation: p.InitialDeviation, Parameters: p, Config: c.withDefaults()}
+}
+
+// Win is called when a player has won a match against another player, described by their rating and deviation. This function will handle updating the calling Player only. To add the loss to the opponent's rating, call Opponent.Lose(Player) as appropriate.
+func (p *Player) Win(rating, deviation float64) Outcome {
+	return p.headToHead(rating, deviation, []int{1, 2})
+}
+
+// Lose is called when a player has lost a match against another player, described by their rating and deviation. This function will handle updating the calling Player only. To add the win to the opponent's rating, call Opponent.Win(Player) as appropriate.
+func (p *Player) Lose(rating, deviation float64) Outcome {
+	return p.headToHead(rating, deviation, []int{2, 1})
+}
+
+// Draw is called when a player has tied in a match against another player, described by their rating and deviation. This function will handle updating the calling Player only. To add the draw to the opponent's rating, call Opponent.Draw(Player) as appropriate.
+func (p *Player) Draw(rating, deviation float64) Outcome {
+	return p.headToHead(rating, deviation, []int{1, 1})
+}
+
+// Reset will wipe the calling Player's history completely, and revert the current Rating and Deviation to the initial values.
+func (p *Player) Reset() {
+	p.History = []Result{}
+	p.Deviation = p.Parameters.InitialDeviation
+	p.Rating = p.Parameters.InitialRating
+}
+
+// NewPeriod takes the calling Player's current Rating and Deviation, and sets them as the new initital values before resetting the player's history to empty.
+func (p *Player) NewPeriod() {
+	p.Parameters.InitialDeviation = p.Deviation
+	p.Parameters.InitialRating = p.Rating
+	p.Reset()
+}
+
+// ErrRanks is returned by Rate when ranks does not have one entry per team.
+var ErrRanks = errors.New("trueskill: ranks must have one entry per team")
+
+// Rate rates a match between any number of teams of any size, using the TrueSkill factor graph. Ranks gives each team's finishing position, where a lower rank finished ahead of a higher one and teams with equal ranks drew. If ranks is nil, the teams are assumed to have finished in the order given with no draws. The Config of the first player is used for the whole match. Every player's Rating and Deviation is updated and a Result is added to their History. The returned Outcomes are grouped and ordered in the same way as teams. If ranks does not have one entry per team, ErrRanks is returned and no player is changed.
+func Rate(teams []Team, ranks []int) ([][]Outcome, error) {
+	if ranks == nil {
+		ranks = make([]int, len(teams))
+		for i := range ranks {
+			ranks[i] = i
+		}
+	}
+	if len(ranks) != len(teams) {
+		return nil, ErrRanks
+	}
+	quality := Quality(teams)
+	ratings := rate(teams, ranks)
+	outcomes := make([][]Outcome, len(teams))
+	for i, t := range teams {
+		ahead, tied := 0, -1
+		for _, r := range ranks {
+			if r < ranks[i] {
+				ahead++
+			} else if r == ranks[i] {
+				tied++
+			}
+		}
+		outcomes[i] = make([]Outcome, len(t.Players))
+		for j, p := range t.Players {
+			outcomes[i][j] = p.apply(ratings[i][j])
+			p.History = append(p.History, Result{Teams: len(teams), Ahead: ahead, Tied: tied, Weight: t.weight(j), Quality: quality})
+		}
+	}
+	return outcomes, nil
+}
+
+func (p *Player) headToHead(rating, deviation float64, ranks []int) Outcome {
+	opponent := p.Config.NewPlayer(Parameters{InitialRating: rating, InitialDeviation: deviation})
+	teams := []Team{{Players: []*Player{p}}, {Players: []*Player{opponent}}}
+	quality := Quality(teams)
+	outcome := p.apply(rate(teams, ranks)[0][0])
+	tied := 0
+	if ranks[0] == ranks[1] {
+		tied = 1
+	}
+	ahead := 0
+	if ranks[1] < ranks[0] {
+		ahead = 1
+	}
+	p.History = append(p.History, Result{Teams: 2, Ahead: ahead, Tied: tied, Weight: 1, Quality: quality})
+	return outcome
+}
+
+func (p *Player) apply(g gaussian) Outcome {
+	rating, deviation := g.mu(), g.sigma()
+	outcome := Outcome{
+		Rating:         rating,
+		RatingDelta:    rating - p.Rating,
+		Deviation:      deviation,
+		DeviationDelta: deviation - p.Deviation,
+	}
+	p.Rating = rating
+	p.Deviation = deviation
+	return outcome
+}
+
+func (c Config) withDefaults() Config {
+	if c.Beta == 0 {
+		c.Beta = DefaultBeta
+	}
+	if c.Dynamic == 0 {
+		c.Dynamic = DefaultDynamic
+	}
+	if c.DrawProbability == 0 {
+		c.DrawProbability = DefaultDrawProbability
+	}
+	if c.ConvergenceTolerance == 0 {
+		c.ConvergenceTolerance = DefaultConvergenceTolerance
+	}
+	return c
+}
+
+func (c Config) drawMargin(players int) float64 {
+	return normal.PPF((c.DrawProbability+1)/2) * math.Sqrt(float64(players)) * c.Beta
+}
+
+func (t Team) weight(i int) float64 {
+	if t.Weights == nil {
+		return 1
+	}
+	return t.Weights[i]
+}
+
+// rate builds and runs the factor graph for a match and returns each player's posterior skill, grouped and ordered in the same way as teams.
+func rate(teams []Team, ranks []int) [][]gaussian {
+	if len(teams) < 2 {
+		posteriors := make([][]gaussian, len(teams))
+		for i, t := range teams {
+			posteriors[i] = make([]gaussian, len(t.Players))
+			for j, p := range t.Players {
+				posteriors[i][j] = newGaussian(p.Rating, p.Deviation)
+			}
+		}
+		return posteriors
+	}
+	c := teams[0].Players[0].Config
+	order := make([]int, len(teams))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return ranks[order[a]] < ranks[order[b]]
+	})
+
+	var priors []*priorFactor
+	var likelihoods []*likelihoodFactor
+	teamPerformances := make([]*sumFactor, len(teams))
+	skills := make([][]*variable, len(teams))
+	for i, index := range order {
+		t := teams[index]
+		sum := &sumFactor{sum: newEdge(&variable{})}
+		skills[i] = make([]*variable, len(t.Players))
+		for j, p := range t.Players {
+			skill, performance := &variable{}, &variable{}
+			skills[i][j] = skill
+			priors = append(priors, &priorFactor{
+				edge:  newEdge(skill),
+				value: newGaussian(p.Rating, math.Sqrt(math.Pow(p.Deviation, 2)+math.Pow(c.Dynamic, 2))),
+			})
+			likelihoods = append(likelihoods, &likelihoodFactor{
+				mean:     newEdge(skill),
+				value:    newEdge(performance),
+				variance: math.Pow(c.Beta, 2),
+			})
+			sum.terms = append(sum.terms, newEdge(performance))
+			sum.coeffs = append(sum.coeffs, t.weight(j))
+		}
+		teamPerformances[i] = sum
+	}
+
+	differences := make([]*sumFactor, len(teams)-1)
+	truncations := make([]*truncateFactor, len(teams)-1)
+	for i := range differences {
+		difference := &variable{}
+		differences[i] = &sumFactor{
+			sum:    newEdge(difference),
+			terms:  []*edge{newEdge(teamPerformances[i].sum.variable), newEdge(teamPerformances[i+1].sum.variable)},
+			coeffs: []float64{1, -1},
+		}
+		players := len(teams[order[i]].Players) + len(teams[order[i+1]].Players)
+		truncations[i] = &truncateFactor{
+			edge:       newEdge(difference),
+			draw:       ranks[order[i]] == ranks[order[i+1]],
+			drawMargin: c.drawMargin(players),
+		}
+	}
+
+	for _, f := range priors {
+		f.down()
+	}
+	for _, f := range likelihoods {
+		f.down()
+	}
+	for _, f := range teamPerformances {
+		f.down()
+	}
+	last := len(differences) - 1
+	for iteration := 0; iteration < 10; iteration++ {
+		delta := 0.0
+		if last == 0 {
+			differences[0].down()
+			delta = truncations[0].up()
+		} else {
+			for i := 0; i < last; i++ {
+				differences[i].down()
+				delta = math.Max(delta, truncations[i].up())
+				differences[i].up(1)
+			}
+			for i := last; i > 0; i-- {
+				differences[i].down()
+				delta = math.Max(delta, truncations[i].up())
+				differences[i].up(0)
+			}
+		}
+		if delta <= c.ConvergenceTolerance {
+			break
+		}
+	}
+	differences[0].up(0)
+	differences[last].up(1)
+	for _, f := range teamPerformances {
+		for i := range f.terms {
+			f.up(i)
+		}
+	}
+	for _, f := range likelihoods {
+		f.up()
+	}
+
+	posteriors := make([][]gaussian, len(teams))
+	for i, index := range order {
+		posteriors[index] = make([]gaussian, len(skills[i]))
+		for j, skill := range skills[i] {
+			posteriors[index][j] = skill.value
+		}
+	}
+	return posteriors
+}
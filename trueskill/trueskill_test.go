@@ -0,0 +1,141 @@
+package trueskill
+
+import (
+	"math"
+	"testing"
+)
+
+// The expected values below are the published reference values from Jeff Moser's C# implementation, which are also used by the Python trueskill package.
+
+type expectation struct {
+	rating, deviation float64
+}
+
+func newTeams(sizes ...int) []Team {
+	teams := make([]Team, len(sizes))
+	for i, size := range sizes {
+		for j := 0; j < size; j++ {
+			teams[i].Players = append(teams[i].Players, NewPlayer(Parameters{}))
+		}
+	}
+	return teams
+}
+
+func check(t *testing.T, name string, outcomes [][]Outcome, expected []expectation) {
+	t.Helper()
+	i := 0
+	for _, team := range outcomes {
+		for _, o := range team {
+			if math.Abs(o.Rating-expected[i].rating) > .001 || math.Abs(o.Deviation-expected[i].deviation) > .001 {
+				t.Log(name, i, o, expected[i])
+				t.Fail()
+			}
+			i++
+		}
+	}
+	if i != len(expected) {
+		t.Log(name, outcomes)
+		t.Fail()
+	}
+}
+
+// rated calls Rate and fails the test if it returns an error.
+func rated(t *testing.T, teams []Team, ranks []int) [][]Outcome {
+	t.Helper()
+	outcomes, err := Rate(teams, ranks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return outcomes
+}
+
+func checkQuality(t *testing.T, name string, teams []Team, expected float64) {
+	t.Helper()
+	if q := Quality(teams); math.Abs(q-expected) > .001 {
+		t.Log(name, q, expected)
+		t.Fail()
+	}
+}
+
+func TestHeadToHead(t *testing.T) {
+	checkQuality(t, "1v1", newTeams(1, 1), 0.447)
+	check(t, "1v1", rated(t, newTeams(1, 1), nil), []expectation{{29.396, 7.171}, {20.604, 7.171}})
+	check(t, "1v1 draw", rated(t, newTeams(1, 1), []int{0, 0}), []expectation{{25.000, 6.458}, {25.000, 6.458}})
+
+	p := NewPlayer(Parameters{})
+	if o := p.Win(DefaultInitialRating, DefaultInitialDeviation); math.Abs(o.Rating-29.396) > .001 || math.Abs(o.Deviation-7.171) > .001 {
+		t.Log(o)
+		t.Fail()
+	}
+	if p.History[0].Teams != 2 || p.History[0].Ahead != 0 || p.History[0].Tied != 0 || math.Abs(p.History[0].Quality-0.447) > .001 {
+		t.Log(p.History)
+		t.Fail()
+	}
+
+	p = NewPlayer(Parameters{})
+	if o := p.Lose(DefaultInitialRating, DefaultInitialDeviation); math.Abs(o.Rating-20.604) > .001 || p.History[0].Ahead != 1 {
+		t.Log(o, p.History)
+		t.Fail()
+	}
+
+	p = NewPlayer(Parameters{})
+	if o := p.Draw(DefaultInitialRating, DefaultInitialDeviation); math.Abs(o.Deviation-6.458) > .001 || p.History[0].Tied != 1 {
+		t.Log(o, p.History)
+		t.Fail()
+	}
+}
+
+func TestTeams(t *testing.T) {
+	checkQuality(t, "2v2", newTeams(2, 2), 0.447)
+	check(t, "2v2", rated(t, newTeams(2, 2), nil), []expectation{{28.108, 7.774}, {28.108, 7.774}, {21.892, 7.774}, {21.892, 7.774}})
+	check(t, "2v2 draw", rated(t, newTeams(2, 2), []int{0, 0}), []expectation{{25, 7.455}, {25, 7.455}, {25, 7.455}, {25, 7.455}})
+
+	checkQuality(t, "1v2", newTeams(1, 2), 0.135)
+	check(t, "1v2", rated(t, newTeams(1, 2), nil), []expectation{{33.730, 7.317}, {16.270, 7.317}, {16.270, 7.317}})
+	check(t, "1v2 draw", rated(t, newTeams(1, 2), []int{0, 0}), []expectation{{31.660, 7.138}, {18.340, 7.138}, {18.340, 7.138}})
+
+	checkQuality(t, "1v3", newTeams(1, 3), 0.012)
+	check(t, "1v3", rated(t, newTeams(1, 3), nil), []expectation{{36.337, 7.527}, {13.663, 7.527}, {13.663, 7.527}, {13.663, 7.527}})
+}
+
+func TestFreeForAll(t *testing.T) {
+	checkQuality(t, "3 players", newTeams(1, 1, 1), 0.200)
+	check(t, "3 players", rated(t, newTeams(1, 1, 1), nil), []expectation{{31.675, 6.656}, {25.000, 6.208}, {18.325, 6.656}})
+	check(t, "3 players draw", rated(t, newTeams(1, 1, 1), []int{0, 0, 0}), []expectation{{25.000, 5.698}, {25.000, 5.695}, {25.000, 5.698}})
+
+	checkQuality(t, "4 players", newTeams(1, 1, 1, 1), 0.089)
+	check(t, "4 players", rated(t, newTeams(1, 1, 1, 1), nil), []expectation{{33.207, 6.348}, {27.401, 5.787}, {22.599, 5.787}, {16.793, 6.348}})
+
+	checkQuality(t, "5 players", newTeams(1, 1, 1, 1, 1), 0.040)
+	check(t, "5 players", rated(t, newTeams(1, 1, 1, 1, 1), nil), []expectation{{34.363, 6.136}, {29.058, 5.536}, {25.000, 5.420}, {20.942, 5.536}, {15.637, 6.136}})
+
+	teams := newTeams(1, 1, 1)
+	outcomes := rated(t, []Team{teams[2], teams[0], teams[1]}, []int{3, 1, 2})
+	check(t, "3 players unsorted", [][]Outcome{outcomes[1], outcomes[2], outcomes[0]}, []expectation{{31.675, 6.656}, {25.000, 6.208}, {18.325, 6.656}})
+	if r := teams[2].Players[0].History[0]; r.Ahead != 2 || r.Teams != 3 || r.Tied != 0 {
+		t.Log(r)
+		t.Fail()
+	}
+
+	teams = newTeams(1, 1)
+	if _, err := Rate(teams, []int{1, 2, 3}); err != ErrRanks || len(teams[0].Players[0].History) != 0 {
+		t.Log(err, teams[0].Players[0])
+		t.Fail()
+	}
+}
+
+func TestPartialPlay(t *testing.T) {
+	teams := newTeams(1, 2)
+	teams[0].Weights = []float64{0.5}
+	teams[1].Weights = []float64{0.5, 0.5}
+	check(t, "half", rated(t, teams, nil), []expectation{{33.939, 7.312}, {16.061, 7.312}, {16.061, 7.312}})
+
+	teams = newTeams(1, 2)
+	teams[1].Weights = []float64{0, 1}
+	teams[0].Weights = []float64{1}
+	check(t, "absent", rated(t, teams, nil), []expectation{{29.440, 7.166}, {25.000, 8.333}, {20.560, 7.166}})
+
+	teams = newTeams(1, 2)
+	teams[1].Weights = []float64{0.5, 1}
+	check(t, "partial", rated(t, teams, nil), []expectation{{32.417, 7.056}, {21.291, 8.033}, {17.583, 7.056}})
+}
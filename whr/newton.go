@@ -0,0 +1,106 @@
+package whr
+
+import (
+	"math"
+)
+
+// update takes one Newton step on the player's whole history, and returns the largest change in any of the player's ratings in rating points. The Hessian of the log posterior is tridiagonal, because the Wiener process only links consecutive days, so each step takes time linear in the number of days.
+func (r *Rater) update(p *Player) float64 {
+	n := len(p.Days)
+	if n == 0 {
+		return 0
+	}
+	c := r.Config
+	w2 := c.variance()
+
+	// diagonal and off hold the negated Hessian A, where off[i] links day i and day i+1, and gradient holds the gradient of the log posterior.
+	diagonal := make([]float64, n)
+	off := make([]float64, n)
+	gradient := make([]float64, n)
+	for i, d := range p.Days {
+		for _, g := range d.games {
+			e := expected(d.r+g.advantage, g.opponent.r)
+			gradient[i] += g.score - e
+			diagonal[i] += e * (1 - e)
+		}
+		if i == 0 {
+			// The first day carries a virtual draw, one win and one loss, against a player at the Center of the scale.
+			e := expected(d.r, 0)
+			gradient[i] += 1 - 2*e
+			diagonal[i] += 2 * e * (1 - e)
+		}
+		if i < n-1 {
+			next := p.Days[i+1]
+			precision := 1 / (w2 * float64(next.Day-d.Day))
+			gradient[i] += (next.r - d.r) * precision
+			gradient[i+1] -= (next.r - d.r) * precision
+			diagonal[i] += precision
+			diagonal[i+1] += precision
+			off[i] = -precision
+		}
+	}
+
+	step := solve(diagonal, off, gradient)
+	change := 0.0
+	for i, d := range p.Days {
+		d.r += step[i]
+		change = math.Max(change, math.Abs(step[i])/c.q())
+	}
+
+	variances, covariances := covariance(diagonal, off)
+	for i, d := range p.Days {
+		d.variance = variances[i]
+		d.covariance = covariances[i]
+		d.Rating = c.fromR(d.r)
+		d.Deviation = math.Sqrt(d.variance) / c.q()
+	}
+	return change
+}
+
+func expected(r, opponent float64) float64 {
+	return 1 / (1 + math.Exp(opponent-r))
+}
+
+// solve returns x such that A x = b, where the symmetric tridiagonal matrix A has the given diagonal and off diagonal, using the Thomas algorithm.
+func solve(diagonal, off, b []float64) []float64 {
+	n := len(diagonal)
+	pivots := make([]float64, n)
+	y := make([]float64, n)
+	pivots[0] = diagonal[0]
+	y[0] = b[0]
+	for i := 1; i < n; i++ {
+		factor := off[i-1] / pivots[i-1]
+		pivots[i] = diagonal[i] - factor*off[i-1]
+		y[i] = b[i] - factor*y[i-1]
+	}
+	x := make([]float64, n)
+	x[n-1] = y[n-1] / pivots[n-1]
+	for i := n - 2; i >= 0; i-- {
+		x[i] = (y[i] - off[i]*x[i+1]) / pivots[i]
+	}
+	return x
+}
+
+// covariance returns the diagonal of the inverse of the symmetric tridiagonal matrix A, and the entries just above it, using its forward and backward pivots.
+func covariance(diagonal, off []float64) ([]float64, []float64) {
+	n := len(diagonal)
+	forward := make([]float64, n)
+	backward := make([]float64, n)
+	forward[0] = diagonal[0]
+	for i := 1; i < n; i++ {
+		forward[i] = diagonal[i] - math.Pow(off[i-1], 2)/forward[i-1]
+	}
+	backward[n-1] = diagonal[n-1]
+	for i := n - 2; i >= 0; i-- {
+		backward[i] = diagonal[i] - math.Pow(off[i], 2)/backward[i+1]
+	}
+	variances := make([]float64, n)
+	covariances := make([]float64, n)
+	for i := range variances {
+		variances[i] = 1 / (forward[i] + backward[i] - diagonal[i])
+	}
+	for i := 0; i < n-1; i++ {
+		covariances[i] = -off[i] * variances[i+1] / forward[i]
+	}
+	return variances, covariances
+}
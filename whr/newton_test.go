@@ -0,0 +1,42 @@
+package whr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTridiagonal(t *testing.T) {
+	diagonal := []float64{4, 5, 3, 6}
+	off := []float64{-1, -2, -0.5, 0}
+	b := []float64{1, 2, 3, 4}
+	x := solve(diagonal, off, b)
+	for i := range x {
+		ax := diagonal[i] * x[i]
+		if i > 0 {
+			ax += off[i-1] * x[i-1]
+		}
+		if i < len(x)-1 {
+			ax += off[i] * x[i+1]
+		}
+		if math.Abs(ax-b[i]) > 1e-12 {
+			t.Log(i, ax, b[i])
+			t.Fail()
+		}
+	}
+
+	// Each column of the inverse solves A x = e_j.
+	variances, covariances := covariance(diagonal, off)
+	for j := range diagonal {
+		e := make([]float64, len(diagonal))
+		e[j] = 1
+		column := solve(diagonal, off, e)
+		if math.Abs(column[j]-variances[j]) > 1e-12 {
+			t.Log(j, column, variances)
+			t.Fail()
+		}
+		if j > 0 && math.Abs(column[j-1]-covariances[j-1]) > 1e-12 {
+			t.Log(j, column, covariances)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package whr
+
+import (
+	"math"
+)
+
+// Expected returns the expected score of a player with the given rating against an opponent, which is their probability of winning when draws are counted as half a win. Any advantage held by either side should be added to that side's rating by the caller.
+func (c Config) Expected(rating, opponentRating float64) float64 {
+	c = c.withDefaults()
+	return 1 / (1 + math.Exp(-c.q()*(rating-opponentRating)))
+}
+
+// Gap returns the rating difference at which the higher rated player has the given expected score. It is the inverse of Expected. Probabilities below 0.5 give a negative gap.
+func (c Config) Gap(probability float64) float64 {
+	c = c.withDefaults()
+	return math.Log(probability/(1-probability)) / c.q()
+}
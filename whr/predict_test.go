@@ -0,0 +1,24 @@
+package whr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpected(t *testing.T) {
+	c := DefaultConfig()
+	if e := c.Expected(1900, 1500); math.Abs(e-10.0/11) > 0.000001 {
+		t.Log(e)
+		t.Fail()
+	}
+	if g := c.Gap(10.0 / 11); math.Abs(g-400) > 0.000001 {
+		t.Log(g)
+		t.Fail()
+	}
+	for _, probability := range []float64{0.1, 0.5, 0.75, 0.99} {
+		if e := c.Expected(1500+c.Gap(probability), 1500); math.Abs(e-probability) > 0.000001 {
+			t.Log(probability, e)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package whr implements Rémi Coulom's Whole-History Rating. Each player's rating is modelled as a Wiener process over time, and every rating in a player's history is re-estimated whenever new games arrive, so that later results can inform earlier ratings. Ratings are reported on the same 400 point logistic scale as the elo package.
+package whr
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dylrich/rating"
+)
+
+const (
+
+	// DefaultCenter is the standard value for Config.Center.
+	DefaultCenter = 1500.0
+
+	// DefaultScale is the standard value for Config.Scale.
+	DefaultScale = 400.0
+
+	// DefaultVariance is the standard value for Config.Variance.
+	DefaultVariance = 300.0
+
+	// DefaultSteps is the standard value for Config.Steps.
+	DefaultSteps = 2
+
+	// DefaultMaxIterations is the standard value for Config.MaxIterations.
+	DefaultMaxIterations = 100
+
+	// DefaultConvergenceTolerance is the standard value for Config.ConvergenceTolerance.
+	DefaultConvergenceTolerance = 0.001
+)
+
+// Config contains the system-wide settings for a league. Any zero field is replaced with its package default when the Config is used to create a Rater.
+type Config struct {
+
+	// Center is the rating of the virtual opponent that every player draws against on their first day, which anchors the ratings of the league.
+	Center float64
+
+	// Scale is the logistic scale of the ratings. A difference of Scale points means the higher rated player is expected to score ten times as often as the lower rated one.
+	Scale float64
+
+	// Variance (w²) is the variance, in squared rating points, that a player's rating gains for each day that passes. Larger values allow ratings to change more quickly.
+	Variance float64
+
+	// Advantage is the number of rating points by which the side with home field, the first move, or a similar edge is expected to outperform its rating. It is applied using the Advantage of each rating.Game.
+	Advantage float64
+
+	// Steps is the number of Newton steps taken on each of the two players in a game when it is added, which keeps their ratings current without refitting the whole league.
+	Steps int
+
+	// MaxIterations caps the number of passes over every player made by Rater.Converge.
+	MaxIterations int
+
+	// ConvergenceTolerance is the largest change in any rating, in rating points, below which Rater.Converge stops.
+	ConvergenceTolerance float64
+}
+
+// Rater holds the whole history of a league. Games can be added in any order, and each player's ratings are kept on every day they played.
+type Rater struct {
+	Config  Config
+	players map[string]*Player
+	order   []*Player
+}
+
+// Player holds the rating history of a participant, with one Day for each day on which they played at least one game, in chronological order.
+type Player struct {
+	ID   string
+	Days []*Day
+}
+
+// Day is a player's estimated rating and deviation on a day they played. The values are updated whenever the player's history is re-estimated.
+type Day struct {
+	Day               int
+	Rating, Deviation float64
+	r, variance       float64
+
+	// covariance is the covariance between r on this day and r on the player's next day.
+	covariance float64
+	games      []game
+}
+
+type game struct {
+	opponent         *Day
+	score, advantage float64
+}
+
+// DefaultConfig returns a Config populated with the package default values.
+func DefaultConfig() Config {
+	return Config{
+		Center:               DefaultCenter,
+		Scale:                DefaultScale,
+		Variance:             DefaultVariance,
+		Steps:                DefaultSteps,
+		MaxIterations:        DefaultMaxIterations,
+		ConvergenceTolerance: DefaultConvergenceTolerance,
+	}
+}
+
+// NewRater returns an empty Rater using DefaultConfig.
+func NewRater() *Rater {
+	return DefaultConfig().NewRater()
+}
+
+// NewRater returns an empty Rater that uses the calling Config.
+func (c Config) NewRater() *Rater {
+	return &Rater{Config: c.withDefaults(), players: make(map[string]*Player)}
+}
+
+// Add records a game, using its Period as the day on which it was played. The Score and Advantage have the same meaning as in rating.Game. After the game is added, Config.Steps Newton steps are taken on the histories of both players, so their ratings are kept up to date without refitting the rest of the league. Call Converge to re-estimate every player.
+func (r *Rater) Add(g rating.Game) {
+	a, b := r.player(g.Player), r.player(g.Opponent)
+	da, db := a.day(g.Period), b.day(g.Period)
+	advantage := g.Advantage * r.Config.Advantage * r.Config.q()
+	da.games = append(da.games, game{opponent: db, score: g.Score, advantage: advantage})
+	db.games = append(db.games, game{opponent: da, score: 1 - g.Score, advantage: -advantage})
+	for i := 0; i < r.Config.Steps; i++ {
+		r.update(a)
+		r.update(b)
+	}
+}
+
+// Iterate makes the given number of passes over every player, taking one Newton step on each player's whole history in each pass.
+func (r *Rater) Iterate(passes int) {
+	for i := 0; i < passes; i++ {
+		for _, p := range r.order {
+			r.update(p)
+		}
+	}
+}
+
+// Converge makes passes over every player until no rating changes by more than Config.ConvergenceTolerance, or Config.MaxIterations passes have been made. It returns the number of passes made.
+func (r *Rater) Converge() int {
+	for i := 1; i <= r.Config.MaxIterations; i++ {
+		change := 0.0
+		for _, p := range r.order {
+			change = math.Max(change, r.update(p))
+		}
+		if change < r.Config.ConvergenceTolerance {
+			return i
+		}
+	}
+	return r.Config.MaxIterations
+}
+
+// Player returns the history of the player with the given ID, or nil if they have not played.
+func (r *Rater) Player(id string) *Player {
+	return r.players[id]
+}
+
+// Players returns every player in the order they first appeared.
+func (r *Rater) Players() []*Player {
+	return append([]*Player(nil), r.order...)
+}
+
+// Estimate returns the player's rating and deviation on any day. Between two days on which the player played, the rating is interpolated along the Wiener process. Before the first and after the last day, the nearest rating is used and the deviation grows with the time elapsed. The second return value is false if the player has not played.
+func (r *Rater) Estimate(id string, day int) (rating.Estimate, bool) {
+	p := r.players[id]
+	if p == nil || len(p.Days) == 0 {
+		return rating.Estimate{}, false
+	}
+	c := r.Config
+	w2 := c.variance()
+	i := sort.Search(len(p.Days), func(i int) bool { return p.Days[i].Day >= day })
+	var mean, variance float64
+	switch {
+	case i < len(p.Days) && p.Days[i].Day == day:
+		mean, variance = p.Days[i].r, p.Days[i].variance
+	case i == 0:
+		first := p.Days[0]
+		mean, variance = first.r, first.variance+w2*float64(first.Day-day)
+	case i == len(p.Days):
+		last := p.Days[i-1]
+		mean, variance = last.r, last.variance+w2*float64(day-last.Day)
+	default:
+		before, after := p.Days[i-1], p.Days[i]
+		span := float64(after.Day - before.Day)
+		u := float64(day-before.Day) / span
+		mean = before.r + u*(after.r-before.r)
+		variance = u*(1-u)*span*w2 + math.Pow(1-u, 2)*before.variance + math.Pow(u, 2)*after.variance + 2*u*(1-u)*before.covariance
+	}
+	return rating.Estimate{Rating: c.fromR(mean), Deviation: math.Sqrt(variance) / c.q()}, true
+}
+
+func (r *Rater) player(id string) *Player {
+	if p, ok := r.players[id]; ok {
+		return p
+	}
+	p := &Player{ID: id}
+	r.players[id] = p
+	r.order = append(r.order, p)
+	return p
+}
+
+// day returns the player's entry for the given day, inserting a new one if needed. A new day starts from the rating of the nearest earlier day, or the following day if it is the player's first.
+func (p *Player) day(day int) *Day {
+	i := sort.Search(len(p.Days), func(i int) bool { return p.Days[i].Day >= day })
+	if i < len(p.Days) && p.Days[i].Day == day {
+		return p.Days[i]
+	}
+	d := &Day{Day: day}
+	if i > 0 {
+		d.r = p.Days[i-1].r
+	} else if i < len(p.Days) {
+		d.r = p.Days[i].r
+	}
+	p.Days = append(p.Days, nil)
+	copy(p.Days[i+1:], p.Days[i:])
+	p.Days[i] = d
+	return d
+}
+
+func (c Config) withDefaults() Config {
+	if c.Center == 0 {
+		c.Center = DefaultCenter
+	}
+	if c.Scale == 0 {
+		c.Scale = DefaultScale
+	}
+	if c.Variance == 0 {
+		c.Variance = DefaultVariance
+	}
+	if c.Steps == 0 {
+		c.Steps = DefaultSteps
+	}
+	if c.MaxIterations == 0 {
+		c.MaxIterations = DefaultMaxIterations
+	}
+	if c.ConvergenceTolerance == 0 {
+		c.ConvergenceTolerance = DefaultConvergenceTolerance
+	}
+	return c
+}
+
+// q converts rating points to the natural logarithm of a player's strength, r.
+func (c Config) q() float64 {
+	return math.Ln10 / c.Scale
+}
+
+func (c Config) fromR(r float64) float64 {
+	return c.Center + r/c.q()
+}
+
+// variance returns Variance in natural units per day.
+func (c Config) variance() float64 {
+	return c.Variance * math.Pow(c.q(), 2)
+}
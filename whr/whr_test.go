@@ -0,0 +1,123 @@
+package whr
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dylrich/rating"
+)
+
+func TestSingleGame(t *testing.T) {
+	r := NewRater()
+	r.Add(rating.Game{Period: 1, Player: "a", Opponent: "b", Score: 1})
+	r.Converge()
+	a, _ := r.Estimate("a", 1)
+	b, _ := r.Estimate("b", 1)
+	if a.Rating <= 1500 || math.Abs(a.Rating-1500-(1500-b.Rating)) > 0.001 || math.Abs(a.Deviation-b.Deviation) > 0.001 {
+		t.Log(a, b)
+		t.Fail()
+	}
+
+	// At the maximum, the gradient of a's log posterior is zero: the win against b contributes 1 - p, and the virtual draw against the center contributes 1 - 2pc.
+	p := 1 / (1 + math.Pow(10, -(a.Rating-b.Rating)/400))
+	pc := 1 / (1 + math.Pow(10, -(a.Rating-1500)/400))
+	if math.Abs((1-p)+(1-2*pc)) > 0.000001 {
+		t.Log(p, pc)
+		t.Fail()
+	}
+	if _, ok := r.Estimate("c", 1); ok {
+		t.Fail()
+	}
+}
+
+func TestIncremental(t *testing.T) {
+	games := []rating.Game{
+		{Period: 1, Player: "a", Opponent: "b", Score: 1},
+		{Period: 1, Player: "b", Opponent: "c", Score: 1},
+		{Period: 3, Player: "c", Opponent: "a", Score: 1},
+		{Period: 7, Player: "a", Opponent: "c", Score: 0.5},
+		{Period: 10, Player: "b", Opponent: "a", Score: 1, Advantage: 1},
+	}
+	c := DefaultConfig()
+	c.Advantage = 50
+	c.ConvergenceTolerance = 0.000001
+	incremental, batch := c.NewRater(), c.NewRater()
+	for _, g := range games {
+		incremental.Add(g)
+		incremental.Converge()
+	}
+
+	// Games can be added out of order, and the fit depends only on the set of games.
+	for i := len(games) - 1; i >= 0; i-- {
+		batch.Add(games[i])
+	}
+	batch.Converge()
+	for _, id := range []string{"a", "b", "c"} {
+		p, q := incremental.Player(id), batch.Player(id)
+		if len(p.Days) != len(q.Days) {
+			t.Log(id, p.Days, q.Days)
+			t.Fail()
+			continue
+		}
+		for i := range p.Days {
+			if p.Days[i].Day != q.Days[i].Day || math.Abs(p.Days[i].Rating-q.Days[i].Rating) > 0.001 || math.Abs(p.Days[i].Deviation-q.Days[i].Deviation) > 0.001 {
+				t.Log(id, *p.Days[i], *q.Days[i])
+				t.Fail()
+			}
+		}
+	}
+	if len(batch.Players()) != 3 || batch.Players()[0].ID != "b" {
+		t.Log(batch.Players())
+		t.Fail()
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	r := NewRater()
+	r.Add(rating.Game{Period: 0, Player: "a", Opponent: "b", Score: 1})
+	r.Add(rating.Game{Period: 10, Player: "a", Opponent: "b", Score: 1})
+	r.Add(rating.Game{Period: 10, Player: "a", Opponent: "b", Score: 1})
+	r.Converge()
+	first, _ := r.Estimate("a", 0)
+	middle, _ := r.Estimate("a", 5)
+	last, _ := r.Estimate("a", 10)
+	later, _ := r.Estimate("a", 110)
+	if math.Abs(middle.Rating-(first.Rating+last.Rating)/2) > 0.001 {
+		t.Log(first, middle, last)
+		t.Fail()
+	}
+	if middle.Deviation <= math.Min(first.Deviation, last.Deviation) {
+		t.Log(first, middle, last)
+		t.Fail()
+	}
+	if later.Rating != last.Rating || math.Abs(math.Pow(later.Deviation, 2)-math.Pow(last.Deviation, 2)-100*DefaultVariance) > 0.001 {
+		t.Log(last, later)
+		t.Fail()
+	}
+	if earlier, _ := r.Estimate("a", -100); earlier.Rating != first.Rating || earlier.Deviation <= first.Deviation {
+		t.Log(first, earlier)
+		t.Fail()
+	}
+}
+
+func TestVariance(t *testing.T) {
+	// A player who loses early and wins late improves over time, and more so when ratings are allowed to change more quickly.
+	gap := func(variance float64) float64 {
+		c := DefaultConfig()
+		c.Variance = variance
+		r := c.NewRater()
+		for i := 0; i < 5; i++ {
+			r.Add(rating.Game{Period: 0, Player: "a", Opponent: "b", Score: 0})
+			r.Add(rating.Game{Period: 100, Player: "a", Opponent: "b", Score: 1})
+		}
+		r.Converge()
+		early, _ := r.Estimate("a", 0)
+		late, _ := r.Estimate("a", 100)
+		return late.Rating - early.Rating
+	}
+	slow, fast := gap(1), gap(1000)
+	if slow <= 0 || fast <= slow {
+		t.Log(slow, fast)
+		t.Fail()
+	}
+}